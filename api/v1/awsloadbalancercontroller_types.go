@@ -18,6 +18,7 @@ package v1
 
 import (
 	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -42,6 +43,124 @@ const (
 	ManualSubnetTaggingPolicy SubnetTaggingPolicy = "Manual"
 )
 
+// +kubebuilder:validation:Enum=Public;Internal
+type SubnetRole string
+
+const (
+	// PublicSubnetRole is the role of the subnets used for internet-facing load balancers.
+	PublicSubnetRole SubnetRole = "Public"
+
+	// InternalSubnetRole is the role of the subnets used for internal load balancers.
+	InternalSubnetRole SubnetRole = "Internal"
+)
+
+// SubnetConflictPolicy is the policy used for the subnets having both public and internal role tags.
+// +kubebuilder:validation:Enum=Report;RemoveOperatorTag
+type SubnetConflictPolicy string
+
+const (
+	// ReportSubnetConflictPolicy only reports the conflicting subnets.
+	ReportSubnetConflictPolicy SubnetConflictPolicy = "Report"
+
+	// RemoveOperatorTagSubnetConflictPolicy removes the role tag put by the operator from the conflicting subnets.
+	RemoveOperatorTagSubnetConflictPolicy SubnetConflictPolicy = "RemoveOperatorTag"
+)
+
+// DeletionPolicy is the policy applied to the AWS resources provisioned by the controller
+// when the AWSLoadBalancerController is deleted.
+// +kubebuilder:validation:Enum=Retain;Delete
+type DeletionPolicy string
+
+const (
+	// RetainDeletionPolicy keeps the AWS resources provisioned by the controller.
+	RetainDeletionPolicy DeletionPolicy = "Retain"
+
+	// DeleteDeletionPolicy deletes the load balancers, listeners, target groups and security groups
+	// provisioned by the controller for the cluster.
+	DeleteDeletionPolicy DeletionPolicy = "Delete"
+)
+
+// CredentialsPermissionsCheckPolicy specifies whether the operator checks the IAM permissions of the controller credentials.
+// +kubebuilder:validation:Enum=Enabled;Disabled
+type CredentialsPermissionsCheckPolicy string
+
+const (
+	// EnabledCredentialsPermissionsCheckPolicy simulates the controller IAM policy against the controller credentials.
+	EnabledCredentialsPermissionsCheckPolicy CredentialsPermissionsCheckPolicy = "Enabled"
+
+	// DisabledCredentialsPermissionsCheckPolicy skips the check of the controller credentials permissions.
+	DisabledCredentialsPermissionsCheckPolicy CredentialsPermissionsCheckPolicy = "Disabled"
+)
+
+// TargetType is the type of the targets registered by the controller in the target groups.
+// +kubebuilder:validation:Enum=Instance;IP
+type TargetType string
+
+const (
+	// InstanceTargetType registers the nodes as targets, the traffic reaches the pods through the node ports.
+	InstanceTargetType TargetType = "Instance"
+
+	// IPTargetType additionally allows to register the pod IPs as targets.
+	IPTargetType TargetType = "IP"
+)
+
+// WebhookFailurePolicy is the policy applied by the API server when a controller webhook cannot be called.
+// +kubebuilder:validation:Enum=Fail;Ignore
+type WebhookFailurePolicy string
+
+const (
+	// FailWebhookFailurePolicy rejects the admission requests when the webhook cannot be called.
+	FailWebhookFailurePolicy WebhookFailurePolicy = "Fail"
+
+	// IgnoreWebhookFailurePolicy admits the requests when the webhook cannot be called.
+	IgnoreWebhookFailurePolicy WebhookFailurePolicy = "Ignore"
+)
+
+// LoadBalancerSourceKind is the kind of the resource for which a load balancer was provisioned.
+// +kubebuilder:validation:Enum=Ingress;IngressGroup;Service
+type LoadBalancerSourceKind string
+
+const (
+	// IngressLoadBalancerSourceKind is the kind of the load balancers provisioned for a single Ingress.
+	IngressLoadBalancerSourceKind LoadBalancerSourceKind = "Ingress"
+
+	// IngressGroupLoadBalancerSourceKind is the kind of the load balancers shared by the Ingresses of a group.
+	IngressGroupLoadBalancerSourceKind LoadBalancerSourceKind = "IngressGroup"
+
+	// ServiceLoadBalancerSourceKind is the kind of the load balancers provisioned for a Service.
+	ServiceLoadBalancerSourceKind LoadBalancerSourceKind = "Service"
+)
+
+// SubnetZoneType is the type of the zone where a subnet is located.
+// +kubebuilder:validation:Enum=AvailabilityZone;LocalZone;WavelengthZone;Outpost
+type SubnetZoneType string
+
+const (
+	// AvailabilityZoneSubnetZoneType is the zone type of the subnets located in a regular availability zone.
+	AvailabilityZoneSubnetZoneType SubnetZoneType = "AvailabilityZone"
+
+	// LocalZoneSubnetZoneType is the zone type of the subnets located in a Local Zone.
+	LocalZoneSubnetZoneType SubnetZoneType = "LocalZone"
+
+	// WavelengthZoneSubnetZoneType is the zone type of the subnets located in a Wavelength Zone.
+	WavelengthZoneSubnetZoneType SubnetZoneType = "WavelengthZone"
+
+	// OutpostSubnetZoneType is the zone type of the subnets located on an Outpost.
+	OutpostSubnetZoneType SubnetZoneType = "Outpost"
+)
+
+// SubnetRoleSource is the origin of the role tag of a subnet.
+// +kubebuilder:validation:Enum=User;Operator
+type SubnetRoleSource string
+
+const (
+	// UserSubnetRoleSource is the source of the role tags put by the user.
+	UserSubnetRoleSource SubnetRoleSource = "User"
+
+	// OperatorSubnetRoleSource is the source of the role tags put by the operator.
+	OperatorSubnetRoleSource SubnetRoleSource = "Operator"
+)
+
 // AWSLoadBalancerControllerSpec defines the desired state of AWSLoadBalancerController.
 type AWSLoadBalancerControllerSpec struct {
 	// subnetTagging describes how the subnet tagging will be done by the operator.
@@ -58,6 +177,58 @@ type AWSLoadBalancerControllerSpec struct {
 	// +optional
 	SubnetTagging SubnetTaggingPolicy `json:"subnetTagging,omitempty"`
 
+	// subnetResyncInterval is the interval at which the operator rediscovers the cluster subnets.
+	// On each resync the subnets are classified again, the subnets added to the cluster
+	// (for instance, the subnets of a new availability zone) are tagged when subnetTagging is "Auto"
+	// and the subnets status is updated if anything changed.
+	// The value is a duration string, for instance "30m" or "1h". The minimum value is "1m".
+	// The value will default to "10m".
+	//
+	// +kubebuilder:default:="10m"
+	// +kubebuilder:validation:Type:=string
+	// +kubebuilder:validation:Pattern:=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1m')",message="subnetResyncInterval must be at least 1m"
+	// +kubebuilder:validation:Optional
+	// +optional
+	SubnetResyncInterval *metav1.Duration `json:"subnetResyncInterval,omitempty"`
+
+	// subnets selects the subnets where the load balancers will be provisioned.
+	// When this field is set and subnetTagging is "Auto", the operator tags only the selected subnets
+	// with the role of their selector instead of detecting the roles of the cluster subnets.
+	// The role tags previously added by the operator to the subnets which are not selected anymore are removed.
+	// The selected subnets must belong to the cluster VPC.
+	// This field has no effect when subnetTagging is "Manual".
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Subnets *AWSLoadBalancerControllerSubnets `json:"subnets,omitempty"`
+
+	// subnetConflictPolicy describes how the operator handles the subnets which have both
+	// `kubernetes.io/role/elb` and `kubernetes.io/role/internal-elb` tags.
+	// Allowed values are "Report" and "RemoveOperatorTag". The default value is "Report".
+	// The conflicting subnets are always reported in the status and in the SubnetsDegraded condition,
+	// they are not used by the operator until the conflict is resolved.
+	// When this field is set to "RemoveOperatorTag" and subnetTagging is "Auto", the operator resolves
+	// the conflict by removing the role tag it put on the subnet, the role tag put by the user is kept.
+	// The conflicting subnets which were not tagged by the operator are only reported.
+	//
+	// +kubebuilder:default:=Report
+	// +kubebuilder:validation:Optional
+	// +optional
+	SubnetConflictPolicy SubnetConflictPolicy `json:"subnetConflictPolicy,omitempty"`
+
+	// minSubnetAvailableIPAddresses is the minimum number of available IPv4 addresses
+	// which each public and internal subnet is expected to have. The load balancers fail to provision
+	// in the subnets running out of IP addresses, the operator reports the subnets below this threshold
+	// in the SubnetsDegraded condition. The value 0 disables the check.
+	// The value will default to 8 which is the number of the free IP addresses required by an application load balancer.
+	//
+	// +kubebuilder:default:=8
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:validation:Optional
+	// +optional
+	MinSubnetAvailableIPAddresses *int32 `json:"minSubnetAvailableIPAddresses,omitempty"`
+
 	// additionalResourceTags are the AWS tags that will be applied to all AWS resources managed by this
 	// controller. The managed AWS resources don't include the cluster subnets which are tagged by the operator.
 	// The addition of new tags as well as the update or removal of any existing tags
@@ -129,6 +300,458 @@ type AWSLoadBalancerControllerSpec struct {
 	// +kubebuilder:validation:Optional
 	// +optional
 	CredentialsRequestConfig *AWSLoadBalancerCredentialsRequestConfig `json:"credentialsRequestConfig,omitempty"`
+
+	// credentialsPermissionsCheck specifies whether the operator checks that the controller credentials
+	// are allowed to perform the actions of the controller IAM policy. The allowed values are:
+	// `Enabled`: the IAM policies of the credentials principal are simulated with `iam:SimulatePrincipalPolicy`
+	// and the denied actions are reported in the `CredentialsPermissionsValid` condition.
+	// `Disabled`: the check is skipped, for instance when the operator is not allowed to simulate the IAM policies.
+	//
+	// +kubebuilder:default:=Enabled
+	// +kubebuilder:validation:Optional
+	// +optional
+	CredentialsPermissionsCheck CredentialsPermissionsCheckPolicy `json:"credentialsPermissionsCheck,omitempty"`
+
+	// deletionPolicy specifies what happens to the AWS resources provisioned by the controller
+	// when this resource is deleted. The allowed values are:
+	// `Retain`: the load balancers, listeners, target groups and security groups are kept.
+	// `Delete`: the load balancers, listeners, target groups and security groups tagged with
+	// `elbv2.k8s.aws/cluster: <cluster name>` are deleted before this resource is removed.
+	//
+	// +kubebuilder:default:=Retain
+	// +kubebuilder:validation:Optional
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// webhooks configures the admission webhooks of the controller
+	// for the Ingress and TargetGroupBinding resources.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Webhooks *AWSLoadBalancerControllerWebhooks `json:"webhooks,omitempty"`
+
+	// targetType specifies the target types allowed for the load balancers. The allowed values are:
+	// `Instance`: only the nodes are registered as targets, the IP target type is rejected.
+	// `IP`: the pods can be registered as targets with the `alb.ingress.kubernetes.io/target-type: ip` annotation.
+	// The controller injects the pod readiness gates into the pods of the namespaces
+	// labelled with `elbv2.k8s.aws/pod-readiness-gate-inject: enabled`
+	// so that the rollouts wait for the new pods to become healthy targets.
+	//
+	// +kubebuilder:default:=Instance
+	// +kubebuilder:validation:Optional
+	// +optional
+	TargetType TargetType `json:"targetType,omitempty"`
+
+	// featureGates enables or disables the feature gates of the controller.
+	// The keys are the names of the upstream feature gates, for instance
+	// `ServiceTypeLoadBalancerOnly`, `EnableRGTAPI`, `SubnetsClusterTagCheck`,
+	// `NLBSecurityGroup` or `ListenerRulesTagging`, for more info see
+	// https://kubernetes-sigs.github.io/aws-load-balancer-controller/latest/deploy/configurations/#feature-gates.
+	// The gates which are not supported by the version of the controller
+	// are not passed to the controller and are reported in the `FeatureGatesValid` condition.
+	// `EnableIPTargetType` is managed through the `targetType` field.
+	//
+	// +kubebuilder:validation:MaxProperties:=32
+	// +kubebuilder:validation:Optional
+	// +optional
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+
+	// controllerConfig specifies the advanced settings of the controller
+	// which are passed to the controller as flags.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	ControllerConfig *AWSLoadBalancerControllerConfig `json:"controllerConfig,omitempty"`
+
+	// ingressClassParams specifies the IngressClassParams referenced by the Ingress class.
+	// The IngressClassParams enforce the settings of the load balancers of all the Ingresses of the class
+	// regardless of their annotations. The IngressClassParams is created with the name of the Ingress class.
+	// When this field is omitted the Ingress class doesn't reference any IngressClassParams.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	IngressClassParams *AWSLoadBalancerIngressClassParams `json:"ingressClassParams,omitempty"`
+
+	// ingressClasses specifies the Ingress classes provisioned in addition to ingressClass.
+	// Each Ingress class can reference its own IngressClassParams, for instance to separate
+	// the internal and the internet-facing load balancers.
+	// The Ingress classes removed from this list are deleted.
+	//
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	IngressClasses []AWSLoadBalancerIngressClass `json:"ingressClasses,omitempty"`
+
+	// defaultIngressClass marks ingressClass as the default class of the cluster
+	// with the `ingressclass.kubernetes.io/is-default-class` annotation.
+	// The Ingresses without a class are then reconciled by the controller instead of the OpenShift router.
+	// The annotation is not set while another Ingress class is the default class of the cluster,
+	// the conflict is reported in the DefaultIngressClassConflict condition.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	DefaultIngressClass bool `json:"defaultIngressClass,omitempty"`
+}
+
+// AWSLoadBalancerIngressClass defines an Ingress class served by the controller.
+type AWSLoadBalancerIngressClass struct {
+	// name is the name of the Ingress class.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	// +required
+	Name string `json:"name"`
+
+	// ingressClassParams specifies the IngressClassParams referenced by the Ingress class.
+	// The IngressClassParams is created with the name of the Ingress class.
+	// When this field is omitted the Ingress class doesn't reference any IngressClassParams.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	IngressClassParams *AWSLoadBalancerIngressClassParams `json:"ingressClassParams,omitempty"`
+
+	// default marks the Ingress class as the default class of the cluster
+	// with the `ingressclass.kubernetes.io/is-default-class` annotation.
+	// The Ingresses without a class are then assigned to this Ingress class.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Default bool `json:"default,omitempty"`
+}
+
+// LoadBalancerScheme is the scheme of a load balancer.
+// +kubebuilder:validation:Enum=internal;internet-facing
+type LoadBalancerScheme string
+
+const (
+	// InternalLoadBalancerScheme is the scheme of the load balancers reachable from the VPC only.
+	InternalLoadBalancerScheme LoadBalancerScheme = "internal"
+
+	// InternetFacingLoadBalancerScheme is the scheme of the load balancers reachable from the internet.
+	InternetFacingLoadBalancerScheme LoadBalancerScheme = "internet-facing"
+)
+
+// LoadBalancerIPAddressType is the type of the IP addresses of a load balancer.
+// +kubebuilder:validation:Enum=ipv4;dualstack;dualstack-without-public-ipv4
+type LoadBalancerIPAddressType string
+
+const (
+	// IPv4LoadBalancerIPAddressType assigns the IPv4 addresses only.
+	IPv4LoadBalancerIPAddressType LoadBalancerIPAddressType = "ipv4"
+
+	// DualStackLoadBalancerIPAddressType assigns the IPv4 and IPv6 addresses.
+	DualStackLoadBalancerIPAddressType LoadBalancerIPAddressType = "dualstack"
+
+	// DualStackWithoutPublicIPv4LoadBalancerIPAddressType assigns the IPv6 addresses and the private IPv4 addresses.
+	DualStackWithoutPublicIPv4LoadBalancerIPAddressType LoadBalancerIPAddressType = "dualstack-without-public-ipv4"
+)
+
+// AWSLoadBalancerIngressClassParams defines the settings enforced on the load balancers of the Ingress class.
+// For more info on the settings see
+// https://kubernetes-sigs.github.io/aws-load-balancer-controller/latest/guide/ingress/ingress_class/#ingressclassparams.
+type AWSLoadBalancerIngressClassParams struct {
+	// scheme is the scheme of the load balancers.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Scheme LoadBalancerScheme `json:"scheme,omitempty"`
+
+	// ipAddressType is the type of the IP addresses of the load balancers.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	IPAddressType LoadBalancerIPAddressType `json:"ipAddressType,omitempty"`
+
+	// group is the name of the Ingress group of all the Ingresses of the class.
+	// The Ingresses of a group share the same load balancer.
+	//
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Optional
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// namespaceSelector restricts the namespaces of the Ingresses of the class.
+	// When omitted, the Ingresses of all the namespaces can use the class.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// tags are the tags added to the AWS resources of the Ingresses of the class.
+	//
+	// +kubebuilder:validation:MaxItems=25
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=key
+	// +optional
+	Tags []AWSResourceTag `json:"tags,omitempty"`
+
+	// loadBalancerAttributes are the attributes of the load balancers.
+	//
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=key
+	// +optional
+	LoadBalancerAttributes []AWSLoadBalancerAttribute `json:"loadBalancerAttributes,omitempty"`
+
+	// sslPolicy is the SSL policy of the HTTPS listeners.
+	//
+	// +kubebuilder:validation:Pattern=`^[0-9A-Za-z-]+$`
+	// +kubebuilder:validation:MaxLength=128
+	// +kubebuilder:validation:Optional
+	// +optional
+	SSLPolicy string `json:"sslPolicy,omitempty"`
+}
+
+// AWSLoadBalancerAttribute is an attribute of a load balancer.
+type AWSLoadBalancerAttribute struct {
+	// key is the name of the attribute, for instance "idle_timeout.timeout_seconds".
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	// +required
+	Key string `json:"key"`
+
+	// value is the value of the attribute.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=1024
+	// +required
+	Value string `json:"value"`
+}
+
+// ControllerLogLevel is the log level of the controller.
+// +kubebuilder:validation:Enum=Info;Debug
+type ControllerLogLevel string
+
+const (
+	// InfoControllerLogLevel logs the informational messages and the errors.
+	InfoControllerLogLevel ControllerLogLevel = "Info"
+
+	// DebugControllerLogLevel additionally logs the debug messages.
+	DebugControllerLogLevel ControllerLogLevel = "Debug"
+)
+
+// AWSAPIThrottle is the throttle setting of the AWS API operations matching a regular expression.
+// +kubebuilder:validation:Pattern=`^[^:,=]+:[^,=]+=[0-9]+(\.[0-9]+)?:[0-9]+$`
+type AWSAPIThrottle string
+
+// AWSLoadBalancerControllerConfig defines the advanced settings of the controller.
+// For more info on the settings see
+// https://kubernetes-sigs.github.io/aws-load-balancer-controller/latest/deploy/configurations/#controller-command-line-flags.
+//
+// +kubebuilder:validation:XValidation:rule="!has(self.backendSecurityGroup) || !has(self.enableBackendSecurityGroup) || self.enableBackendSecurityGroup",message="backendSecurityGroup requires enableBackendSecurityGroup to be true"
+type AWSLoadBalancerControllerConfig struct {
+	// logLevel is the log level of the controller.
+	// Defaults to "Info".
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	LogLevel ControllerLogLevel `json:"logLevel,omitempty"`
+
+	// defaultSSLPolicy is the SSL policy of the HTTPS listeners
+	// which don't specify it with the `alb.ingress.kubernetes.io/ssl-policy` annotation.
+	//
+	// +kubebuilder:validation:Pattern=`^[0-9A-Za-z-]+$`
+	// +kubebuilder:validation:MaxLength=128
+	// +kubebuilder:validation:Optional
+	// +optional
+	DefaultSSLPolicy string `json:"defaultSSLPolicy,omitempty"`
+
+	// defaultTargetType is the target type of the target groups
+	// which don't specify it with the `alb.ingress.kubernetes.io/target-type` annotation.
+	// `IP` requires the `targetType` field to be `IP`.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	DefaultTargetType TargetType `json:"defaultTargetType,omitempty"`
+
+	// syncPeriod is the period at which the controller resyncs all the resources.
+	// The value is a duration string, for instance "10h" or "30m".
+	//
+	// +kubebuilder:validation:Type:=string
+	// +kubebuilder:validation:Pattern:=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1m')",message="syncPeriod must be at least 1m"
+	// +kubebuilder:validation:Optional
+	// +optional
+	SyncPeriod *metav1.Duration `json:"syncPeriod,omitempty"`
+
+	// ingressMaxConcurrentReconciles is the maximum number of the Ingress groups reconciled concurrently.
+	//
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=100
+	// +kubebuilder:validation:Optional
+	// +optional
+	IngressMaxConcurrentReconciles *int32 `json:"ingressMaxConcurrentReconciles,omitempty"`
+
+	// targetGroupBindingMaxConcurrentReconciles is the maximum number of the TargetGroupBindings reconciled concurrently.
+	//
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=100
+	// +kubebuilder:validation:Optional
+	// +optional
+	TargetGroupBindingMaxConcurrentReconciles *int32 `json:"targetGroupBindingMaxConcurrentReconciles,omitempty"`
+
+	// awsMaxRetries is the maximum number of retries of the AWS API calls.
+	//
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:validation:Maximum:=100
+	// +kubebuilder:validation:Optional
+	// +optional
+	AWSMaxRetries *int32 `json:"awsMaxRetries,omitempty"`
+
+	// awsAPIThrottle overrides the throttle settings of the AWS API calls.
+	// Each entry has the `<serviceID>:<operation regex>=<rate>:<burst>` format,
+	// for instance "Elastic Load Balancing v2:Describe.*=10:20".
+	//
+	// +kubebuilder:validation:MaxItems:=32
+	// +kubebuilder:validation:Optional
+	// +optional
+	AWSAPIThrottle []AWSAPIThrottle `json:"awsAPIThrottle,omitempty"`
+
+	// enableBackendSecurityGroup enables the shared security group which allows the traffic
+	// from the load balancers to the targets. Defaults to true.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	EnableBackendSecurityGroup *bool `json:"enableBackendSecurityGroup,omitempty"`
+
+	// backendSecurityGroup is the ID of the shared backend security group.
+	// The controller creates the security group if this field is omitted.
+	//
+	// +kubebuilder:validation:Pattern=`^sg-[0-9a-f]+$`
+	// +kubebuilder:validation:Optional
+	// +optional
+	BackendSecurityGroup string `json:"backendSecurityGroup,omitempty"`
+
+	// disableRestrictedSecurityGroupRules allows all the ports in the security group rules
+	// from the load balancers to the targets instead of the target ports only.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	DisableRestrictedSecurityGroupRules *bool `json:"disableRestrictedSecurityGroupRules,omitempty"`
+}
+
+// AWSLoadBalancerControllerWebhooks defines the configuration of the controller admission webhooks.
+type AWSLoadBalancerControllerWebhooks struct {
+	// failurePolicy defines how the API server handles the requests when the controller
+	// webhooks cannot be called, for instance when no controller replica is ready.
+	// The allowed values are:
+	// `Fail`: the requests are rejected.
+	// `Ignore`: the requests are admitted without the validation and the defaulting of the controller.
+	//
+	// +kubebuilder:default:=Fail
+	// +kubebuilder:validation:Optional
+	// +optional
+	FailurePolicy WebhookFailurePolicy `json:"failurePolicy,omitempty"`
+
+	// timeoutSeconds is the time the API server waits for a webhook call
+	// before applying the failure policy.
+	// Defaults to 10 seconds.
+	//
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=30
+	// +kubebuilder:validation:Optional
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// namespaceSelector selects the namespaces whose resources are sent to the webhooks.
+	// When omitted, the platform namespaces are excluded: the `kube-system`, `kube-public`,
+	// `kube-node-lease` and `openshift` namespaces and the namespaces labelled with `openshift.io/run-level`.
+	// An empty selector selects all the namespaces.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// objectSelector selects the resources sent to the webhooks by their labels.
+	// When omitted, all the resources are selected.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	ObjectSelector *metav1.LabelSelector `json:"objectSelector,omitempty"`
+}
+
+// AWSLoadBalancerControllerSubnets selects the public and internal subnets of the load balancers.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.public) || has(self.internal)",message="at least one of public or internal must be specified"
+type AWSLoadBalancerControllerSubnets struct {
+	// public selects the subnets for the internet-facing load balancers.
+	// The selected subnets are tagged with `kubernetes.io/role/elb`.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Public *SubnetSelector `json:"public,omitempty"`
+
+	// internal selects the subnets for the internal load balancers.
+	// The selected subnets are tagged with `kubernetes.io/role/internal-elb`.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Internal *SubnetSelector `json:"internal,omitempty"`
+}
+
+// SubnetSelector selects subnets by their IDs, availability zones or tags.
+// A subnet is selected if it matches all the specified criteria.
+// The subnets are looked up in the cluster VPC unless ids are specified.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.ids) || has(self.availabilityZones) || has(self.tags)",message="at least one of ids, availabilityZones or tags must be specified"
+type SubnetSelector struct {
+	// ids is the list of the subnet IDs.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MinItems=1
+	// +optional
+	// +listType=set
+	IDs []SubnetID `json:"ids,omitempty"`
+
+	// availabilityZones is the list of the availability zone names, for instance "us-east-1a".
+	// The subnets located in any of the given availability zones match.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MinItems=1
+	// +optional
+	// +listType=set
+	AvailabilityZones []string `json:"availabilityZones,omitempty"`
+
+	// tags is the list of the tags which the subnets must have.
+	// The subnets having all the given tags match.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MinItems=1
+	// +optional
+	// +listType=map
+	// +listMapKey=key
+	Tags []SubnetTagSelector `json:"tags,omitempty"`
+}
+
+// SubnetID is the ID of a subnet.
+//
+// +kubebuilder:validation:Pattern=`^subnet-[0-9a-f]+$`
+type SubnetID string
+
+// SubnetTagSelector matches the subnets having a tag.
+type SubnetTagSelector struct {
+	// key is the key of the tag.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=128
+	// +required
+	Key string `json:"key"`
+
+	// value is the value of the tag.
+	// Any value of the tag matches if this field is empty.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=256
+	// +optional
+	Value string `json:"value,omitempty"`
 }
 
 // AWSResourceTag is a tag to apply to AWS resources created by the controller.
@@ -168,9 +791,51 @@ type AWSLoadBalancerDeploymentConfig struct {
 	// +kubebuilder:validation:Optional
 	// +optional
 	Replicas int32 `json:"replicas,omitempty"`
+
+	// resources are the compute resource requests and limits of the controller container.
+	// No requests or limits are set if empty.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// nodeSelector is the node selector of the controller pods.
+	// It can be used to schedule the controller on the infrastructure nodes.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// tolerations are the tolerations of the controller pods.
+	// They can be used to schedule the controller on tainted nodes.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// affinity is the scheduling constraints of the controller pods.
+	// The pod anti-affinity can be used to spread the controller replicas across the nodes.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
 }
 
+// CredentialsMode is the way the credentials of the controller are provisioned.
+// +kubebuilder:validation:Enum=CredentialsRequest;WebIdentity
+type CredentialsMode string
+
+const (
+	// CredentialsRequestCredentialsMode requests the credentials from the Cloud Credential Operator with a CredentialsRequest.
+	CredentialsRequestCredentialsMode CredentialsMode = "CredentialsRequest"
+
+	// WebIdentityCredentialsMode makes the operator provision the credentials secret which assumes the IAM role
+	// with the service account token of the controller, without the Cloud Credential Operator.
+	WebIdentityCredentialsMode CredentialsMode = "WebIdentity"
+)
+
 // AWSLoadBalancerCredentialsRequestConfig defines customization options for the controller's CredentialsRequest.
+// +kubebuilder:validation:XValidation:rule="!has(self.mode) || self.mode != 'WebIdentity' || (has(self.stsIAMRoleARN) && size(self.stsIAMRoleARN) > 0)",message="stsIAMRoleARN is required in WebIdentity mode"
 type AWSLoadBalancerCredentialsRequestConfig struct {
 	// stsIAMRoleARN is the Amazon Resource Name (ARN) of an IAM Role
 	// which must be manually created for the controller's CredentialsRequest.
@@ -181,6 +846,35 @@ type AWSLoadBalancerCredentialsRequestConfig struct {
 	// +kubebuilder:validation:Optional
 	// +optional
 	STSIAMRoleARN string `json:"stsIAMRoleARN,omitempty"`
+
+	// mode specifies how the credentials of the controller are provisioned. The allowed values are:
+	// `CredentialsRequest`: a CredentialsRequest is created for the Cloud Credential Operator
+	// which provisions the credentials secret.
+	// `WebIdentity`: no CredentialsRequest is created, the operator provisions the credentials secret
+	// which assumes the IAM role from stsIAMRoleARN with the service account token projected into the controller pod.
+	// This mode can be used on the clusters without the Cloud Credential Operator, the IAM role has to trust
+	// the OIDC provider of the cluster for the controller service account.
+	//
+	// +kubebuilder:default:=CredentialsRequest
+	// +kubebuilder:validation:Optional
+	// +optional
+	Mode CredentialsMode `json:"mode,omitempty"`
+
+	// tokenAudience is the audience of the service account token used to assume the IAM role in WebIdentity mode.
+	// It has to match the audience trusted by the IAM role. Defaults to "sts.amazonaws.com".
+	//
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:Optional
+	// +optional
+	TokenAudience string `json:"tokenAudience,omitempty"`
+
+	// tokenExpirationSeconds is the requested validity of the service account token in WebIdentity mode.
+	// The token is refreshed by the kubelet before it expires. Defaults to 3600.
+	//
+	// +kubebuilder:validation:Minimum:=600
+	// +kubebuilder:validation:Optional
+	// +optional
+	TokenExpirationSeconds int64 `json:"tokenExpirationSeconds,omitempty"`
 }
 
 // AWSLoadBalancerControllerStatus defines the observed state of AWSLoadBalancerController.
@@ -215,6 +909,30 @@ type AWSLoadBalancerControllerStatus struct {
 	// +kubebuilder:validation:Optional
 	// +optional
 	IngressClass string `json:"ingressClass,omitempty"`
+
+	// ingressClasses are the names of all the Ingress classes provisioned by the operator,
+	// including ingressClass.
+	//
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	// +optional
+	IngressClasses []string `json:"ingressClasses,omitempty"`
+
+	// loadBalancers is the list of the load balancers provisioned by the controller
+	// for the cluster. The list is refreshed periodically.
+	//
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=arn
+	// +optional
+	LoadBalancers []AWSLoadBalancerStatus `json:"loadBalancers,omitempty"`
+
+	// credentialsRotationTime is the last time the controller was rolled out
+	// because the content of its credentials secret changed.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	CredentialsRotationTime *metav1.Time `json:"credentialsRotationTime,omitempty"`
 }
 
 // AWSLoadBalancerControllerStatusSubnets contains the cluster subnet details
@@ -253,6 +971,149 @@ type AWSLoadBalancerControllerStatusSubnets struct {
 	// +kubebuilder:validation:Optional
 	// +optional
 	Untagged []string `json:"untagged,omitempty"`
+
+	// conflicting is the list of subnet ids which belong to the cluster
+	// and have both `kubernetes.io/role/elb` and `kubernetes.io/role/internal-elb` tags.
+	// The conflicting subnets are not listed as public or internal subnets.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Conflicting []string `json:"conflicting,omitempty"`
+
+	// details is the list of the cluster subnets along with their roles,
+	// the reason why each role was given and their location and addressing.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	// +listType=map
+	// +listMapKey=id
+	Details []AWSLoadBalancerControllerSubnetDetails `json:"details,omitempty"`
+}
+
+// AWSLoadBalancerControllerSubnetDetails contains the details of a cluster subnet.
+type AWSLoadBalancerControllerSubnetDetails struct {
+	// id is the id of the subnet.
+	//
+	// +kubebuilder:validation:Required
+	// +required
+	ID string `json:"id"`
+
+	// role is the load balancer role of the subnet.
+	// Allowed values are "Public" and "Internal".
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Role SubnetRole `json:"role,omitempty"`
+
+	// reason explains how the role of the subnet was determined.
+	// The role is either taken from the role tag put on the subnet by the user
+	// or, when the operator tags the subnet, from the default route of the subnet's route table:
+	// the subnets routing to an internet gateway are public,
+	// the subnets routing through a NAT gateway or without a default route are internal.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// roleSource tells whether the role tag of the subnet was put by the user or by the operator.
+	// Allowed values are "User" and "Operator". This field is empty if the subnet has no role tag.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	RoleSource SubnetRoleSource `json:"roleSource,omitempty"`
+
+	// availabilityZone is the name of the zone where the subnet is located.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+
+	// zoneType is the type of the zone where the subnet is located.
+	// Allowed values are "AvailabilityZone", "LocalZone", "WavelengthZone" and "Outpost".
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	ZoneType SubnetZoneType `json:"zoneType,omitempty"`
+
+	// ipv4CIDR is the IPv4 CIDR block of the subnet.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	IPv4CIDR string `json:"ipv4CIDR,omitempty"`
+
+	// ipv6CIDRs is the list of the IPv6 CIDR blocks associated with the subnet.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	IPv6CIDRs []string `json:"ipv6CIDRs,omitempty"`
+
+	// availableIPAddressCount is the number of the unused IPv4 addresses of the subnet.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	AvailableIPAddressCount int32 `json:"availableIPAddressCount"`
+}
+
+// AWSLoadBalancerStatus describes a load balancer provisioned by the controller.
+type AWSLoadBalancerStatus struct {
+	// arn is the Amazon Resource Name of the load balancer.
+	//
+	// +kubebuilder:validation:Required
+	// +required
+	ARN string `json:"arn"`
+
+	// dnsName is the DNS name of the load balancer.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	DNSName string `json:"dnsName,omitempty"`
+
+	// scheme is the scheme of the load balancer: `internet-facing` or `internal`.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Scheme string `json:"scheme,omitempty"`
+
+	// type is the type of the load balancer: `application` or `network`.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// state is the state of the load balancer: `provisioning`, `active`, `active_impaired` or `failed`.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	State string `json:"state,omitempty"`
+
+	// source is the resource for which the load balancer was provisioned.
+	// The source is not set if the load balancer doesn't have the stack tag of the controller.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Source *LoadBalancerSource `json:"source,omitempty"`
+}
+
+// LoadBalancerSource is the resource for which a load balancer was provisioned.
+type LoadBalancerSource struct {
+	// kind is the kind of the resource: `Ingress`, `IngressGroup` or `Service`.
+	//
+	// +kubebuilder:validation:Required
+	// +required
+	Kind LoadBalancerSourceKind `json:"kind"`
+
+	// namespace is the namespace of the Ingress or the Service.
+	// The namespace is not set for the IngressGroups as they can span multiple namespaces.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// name is the name of the Ingress, the IngressGroup or the Service.
+	//
+	// +kubebuilder:validation:Required
+	// +required
+	Name string `json:"name"`
 }
 
 //+kubebuilder:object:root=true
@@ -266,6 +1127,8 @@ type AWSLoadBalancerController struct {
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
 	// +kubebuilder:validation:XValidation:rule="!has(self.credentials) || !has(self.credentialsRequestConfig)", message="credentialsRequestConfig has no effect if credentials is provided"
+	// +kubebuilder:validation:XValidation:rule="!has(self.ingressClasses) || !self.ingressClasses.exists(c, c.name == self.ingressClass)", message="ingressClasses must not contain ingressClass"
+	// +kubebuilder:validation:XValidation:rule="((has(self.defaultIngressClass) && self.defaultIngressClass) ? 1 : 0) + (has(self.ingressClasses) ? self.ingressClasses.filter(c, has(c.default) && c.default).size() : 0) <= 1", message="only one Ingress class can be the default class"
 	Spec   AWSLoadBalancerControllerSpec   `json:"spec,omitempty"`
 	Status AWSLoadBalancerControllerStatus `json:"status,omitempty"`
 }
@@ -23,10 +23,26 @@ package v1
 
 import (
 	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSLoadBalancerAttribute) DeepCopyInto(out *AWSLoadBalancerAttribute) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSLoadBalancerAttribute.
+func (in *AWSLoadBalancerAttribute) DeepCopy() *AWSLoadBalancerAttribute {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSLoadBalancerAttribute)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSLoadBalancerController) DeepCopyInto(out *AWSLoadBalancerController) {
 	*out = *in
@@ -54,6 +70,56 @@ func (in *AWSLoadBalancerController) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSLoadBalancerControllerConfig) DeepCopyInto(out *AWSLoadBalancerControllerConfig) {
+	*out = *in
+	if in.SyncPeriod != nil {
+		in, out := &in.SyncPeriod, &out.SyncPeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.IngressMaxConcurrentReconciles != nil {
+		in, out := &in.IngressMaxConcurrentReconciles, &out.IngressMaxConcurrentReconciles
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetGroupBindingMaxConcurrentReconciles != nil {
+		in, out := &in.TargetGroupBindingMaxConcurrentReconciles, &out.TargetGroupBindingMaxConcurrentReconciles
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AWSMaxRetries != nil {
+		in, out := &in.AWSMaxRetries, &out.AWSMaxRetries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AWSAPIThrottle != nil {
+		in, out := &in.AWSAPIThrottle, &out.AWSAPIThrottle
+		*out = make([]AWSAPIThrottle, len(*in))
+		copy(*out, *in)
+	}
+	if in.EnableBackendSecurityGroup != nil {
+		in, out := &in.EnableBackendSecurityGroup, &out.EnableBackendSecurityGroup
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DisableRestrictedSecurityGroupRules != nil {
+		in, out := &in.DisableRestrictedSecurityGroupRules, &out.DisableRestrictedSecurityGroupRules
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSLoadBalancerControllerConfig.
+func (in *AWSLoadBalancerControllerConfig) DeepCopy() *AWSLoadBalancerControllerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSLoadBalancerControllerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSLoadBalancerControllerList) DeepCopyInto(out *AWSLoadBalancerControllerList) {
 	*out = *in
@@ -89,6 +155,21 @@ func (in *AWSLoadBalancerControllerList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSLoadBalancerControllerSpec) DeepCopyInto(out *AWSLoadBalancerControllerSpec) {
 	*out = *in
+	if in.SubnetResyncInterval != nil {
+		in, out := &in.SubnetResyncInterval, &out.SubnetResyncInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = new(AWSLoadBalancerControllerSubnets)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MinSubnetAvailableIPAddresses != nil {
+		in, out := &in.MinSubnetAvailableIPAddresses, &out.MinSubnetAvailableIPAddresses
+		*out = new(int32)
+		**out = **in
+	}
 	if in.AdditionalResourceTags != nil {
 		in, out := &in.AdditionalResourceTags, &out.AdditionalResourceTags
 		*out = make([]AWSResourceTag, len(*in))
@@ -97,7 +178,7 @@ func (in *AWSLoadBalancerControllerSpec) DeepCopyInto(out *AWSLoadBalancerContro
 	if in.Config != nil {
 		in, out := &in.Config, &out.Config
 		*out = new(AWSLoadBalancerDeploymentConfig)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.EnabledAddons != nil {
 		in, out := &in.EnabledAddons, &out.EnabledAddons
@@ -114,6 +195,35 @@ func (in *AWSLoadBalancerControllerSpec) DeepCopyInto(out *AWSLoadBalancerContro
 		*out = new(AWSLoadBalancerCredentialsRequestConfig)
 		**out = **in
 	}
+	if in.Webhooks != nil {
+		in, out := &in.Webhooks, &out.Webhooks
+		*out = new(AWSLoadBalancerControllerWebhooks)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ControllerConfig != nil {
+		in, out := &in.ControllerConfig, &out.ControllerConfig
+		*out = new(AWSLoadBalancerControllerConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IngressClassParams != nil {
+		in, out := &in.IngressClassParams, &out.IngressClassParams
+		*out = new(AWSLoadBalancerIngressClassParams)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IngressClasses != nil {
+		in, out := &in.IngressClasses, &out.IngressClasses
+		*out = make([]AWSLoadBalancerIngressClass, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSLoadBalancerControllerSpec.
@@ -141,6 +251,22 @@ func (in *AWSLoadBalancerControllerStatus) DeepCopyInto(out *AWSLoadBalancerCont
 		*out = new(AWSLoadBalancerControllerStatusSubnets)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.IngressClasses != nil {
+		in, out := &in.IngressClasses, &out.IngressClasses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LoadBalancers != nil {
+		in, out := &in.LoadBalancers, &out.LoadBalancers
+		*out = make([]AWSLoadBalancerStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CredentialsRotationTime != nil {
+		in, out := &in.CredentialsRotationTime, &out.CredentialsRotationTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSLoadBalancerControllerStatus.
@@ -176,6 +302,18 @@ func (in *AWSLoadBalancerControllerStatusSubnets) DeepCopyInto(out *AWSLoadBalan
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Conflicting != nil {
+		in, out := &in.Conflicting, &out.Conflicting
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Details != nil {
+		in, out := &in.Details, &out.Details
+		*out = make([]AWSLoadBalancerControllerSubnetDetails, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSLoadBalancerControllerStatusSubnets.
@@ -188,6 +326,81 @@ func (in *AWSLoadBalancerControllerStatusSubnets) DeepCopy() *AWSLoadBalancerCon
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSLoadBalancerControllerSubnetDetails) DeepCopyInto(out *AWSLoadBalancerControllerSubnetDetails) {
+	*out = *in
+	if in.IPv6CIDRs != nil {
+		in, out := &in.IPv6CIDRs, &out.IPv6CIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSLoadBalancerControllerSubnetDetails.
+func (in *AWSLoadBalancerControllerSubnetDetails) DeepCopy() *AWSLoadBalancerControllerSubnetDetails {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSLoadBalancerControllerSubnetDetails)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSLoadBalancerControllerSubnets) DeepCopyInto(out *AWSLoadBalancerControllerSubnets) {
+	*out = *in
+	if in.Public != nil {
+		in, out := &in.Public, &out.Public
+		*out = new(SubnetSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Internal != nil {
+		in, out := &in.Internal, &out.Internal
+		*out = new(SubnetSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSLoadBalancerControllerSubnets.
+func (in *AWSLoadBalancerControllerSubnets) DeepCopy() *AWSLoadBalancerControllerSubnets {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSLoadBalancerControllerSubnets)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSLoadBalancerControllerWebhooks) DeepCopyInto(out *AWSLoadBalancerControllerWebhooks) {
+	*out = *in
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ObjectSelector != nil {
+		in, out := &in.ObjectSelector, &out.ObjectSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSLoadBalancerControllerWebhooks.
+func (in *AWSLoadBalancerControllerWebhooks) DeepCopy() *AWSLoadBalancerControllerWebhooks {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSLoadBalancerControllerWebhooks)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSLoadBalancerCredentialsRequestConfig) DeepCopyInto(out *AWSLoadBalancerCredentialsRequestConfig) {
 	*out = *in
@@ -206,6 +419,30 @@ func (in *AWSLoadBalancerCredentialsRequestConfig) DeepCopy() *AWSLoadBalancerCr
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSLoadBalancerDeploymentConfig) DeepCopyInto(out *AWSLoadBalancerDeploymentConfig) {
 	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSLoadBalancerDeploymentConfig.
@@ -218,6 +455,76 @@ func (in *AWSLoadBalancerDeploymentConfig) DeepCopy() *AWSLoadBalancerDeployment
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSLoadBalancerIngressClass) DeepCopyInto(out *AWSLoadBalancerIngressClass) {
+	*out = *in
+	if in.IngressClassParams != nil {
+		in, out := &in.IngressClassParams, &out.IngressClassParams
+		*out = new(AWSLoadBalancerIngressClassParams)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSLoadBalancerIngressClass.
+func (in *AWSLoadBalancerIngressClass) DeepCopy() *AWSLoadBalancerIngressClass {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSLoadBalancerIngressClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSLoadBalancerIngressClassParams) DeepCopyInto(out *AWSLoadBalancerIngressClassParams) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]AWSResourceTag, len(*in))
+		copy(*out, *in)
+	}
+	if in.LoadBalancerAttributes != nil {
+		in, out := &in.LoadBalancerAttributes, &out.LoadBalancerAttributes
+		*out = make([]AWSLoadBalancerAttribute, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSLoadBalancerIngressClassParams.
+func (in *AWSLoadBalancerIngressClassParams) DeepCopy() *AWSLoadBalancerIngressClassParams {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSLoadBalancerIngressClassParams)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSLoadBalancerStatus) DeepCopyInto(out *AWSLoadBalancerStatus) {
+	*out = *in
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(LoadBalancerSource)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSLoadBalancerStatus.
+func (in *AWSLoadBalancerStatus) DeepCopy() *AWSLoadBalancerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSLoadBalancerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSResourceTag) DeepCopyInto(out *AWSResourceTag) {
 	*out = *in
@@ -232,3 +539,63 @@ func (in *AWSResourceTag) DeepCopy() *AWSResourceTag {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerSource) DeepCopyInto(out *LoadBalancerSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerSource.
+func (in *LoadBalancerSource) DeepCopy() *LoadBalancerSource {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubnetSelector) DeepCopyInto(out *SubnetSelector) {
+	*out = *in
+	if in.IDs != nil {
+		in, out := &in.IDs, &out.IDs
+		*out = make([]SubnetID, len(*in))
+		copy(*out, *in)
+	}
+	if in.AvailabilityZones != nil {
+		in, out := &in.AvailabilityZones, &out.AvailabilityZones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]SubnetTagSelector, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetSelector.
+func (in *SubnetSelector) DeepCopy() *SubnetSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(SubnetSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubnetTagSelector) DeepCopyInto(out *SubnetTagSelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetTagSelector.
+func (in *SubnetTagSelector) DeepCopy() *SubnetTagSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(SubnetTagSelector)
+	in.DeepCopyInto(out)
+	return out
+}
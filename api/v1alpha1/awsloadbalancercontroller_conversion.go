@@ -43,7 +43,11 @@ func (src *AWSLoadBalancerController) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.IngressClass = src.Spec.IngressClass
 	if src.Spec.Config != nil {
 		dst.Spec.Config = &v1.AWSLoadBalancerDeploymentConfig{
-			Replicas: src.Spec.Config.Replicas,
+			Replicas:     src.Spec.Config.Replicas,
+			Resources:    src.Spec.Config.Resources,
+			NodeSelector: src.Spec.Config.NodeSelector,
+			Tolerations:  src.Spec.Config.Tolerations,
+			Affinity:     src.Spec.Config.Affinity,
 		}
 	}
 	for _, addon := range src.Spec.EnabledAddons {
@@ -92,7 +96,11 @@ func (dst *AWSLoadBalancerController) ConvertFrom(srcRaw conversion.Hub) error {
 	dst.Spec.IngressClass = src.Spec.IngressClass
 	if src.Spec.Config != nil {
 		dst.Spec.Config = &AWSLoadBalancerDeploymentConfig{
-			Replicas: src.Spec.Config.Replicas,
+			Replicas:     src.Spec.Config.Replicas,
+			Resources:    src.Spec.Config.Resources,
+			NodeSelector: src.Spec.Config.NodeSelector,
+			Tolerations:  src.Spec.Config.Tolerations,
+			Affinity:     src.Spec.Config.Affinity,
 		}
 	}
 	for _, addon := range src.Spec.EnabledAddons {
@@ -0,0 +1,111 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/openshift/aws-load-balancer-operator/api/v1"
+)
+
+func testDeploymentConfig() *AWSLoadBalancerDeploymentConfig {
+	return &AWSLoadBalancerDeploymentConfig{
+		Replicas: 3,
+		Resources: &corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+			Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+		},
+		NodeSelector: map[string]string{"node-role.kubernetes.io/infra": ""},
+		Tolerations: []corev1.Toleration{
+			{Key: "node-role.kubernetes.io/infra", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+		},
+		Affinity: &corev1.Affinity{
+			PodAntiAffinity: &corev1.PodAntiAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+					{
+						Weight: 100,
+						PodAffinityTerm: corev1.PodAffinityTerm{
+							LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "aws-load-balancer-controller"}},
+							TopologyKey:   "kubernetes.io/hostname",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestConversionRoundTrip(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		config *AWSLoadBalancerDeploymentConfig
+	}{
+		{
+			name: "no config",
+		},
+		{
+			name:   "replicas only",
+			config: &AWSLoadBalancerDeploymentConfig{Replicas: 2},
+		},
+		{
+			name:   "resources, node selector, tolerations and affinity",
+			config: testDeploymentConfig(),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			src := &AWSLoadBalancerController{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Spec: AWSLoadBalancerControllerSpec{
+					SubnetTagging: AutoSubnetTaggingPolicy,
+					IngressClass:  "alb",
+					Config:        tc.config,
+				},
+			}
+
+			hub := &v1.AWSLoadBalancerController{}
+			if err := src.ConvertTo(hub); err != nil {
+				t.Fatalf("failed to convert to v1: %v", err)
+			}
+			var hubConfig *AWSLoadBalancerDeploymentConfig
+			if hub.Spec.Config != nil {
+				hubConfig = &AWSLoadBalancerDeploymentConfig{
+					Replicas:     hub.Spec.Config.Replicas,
+					Resources:    hub.Spec.Config.Resources,
+					NodeSelector: hub.Spec.Config.NodeSelector,
+					Tolerations:  hub.Spec.Config.Tolerations,
+					Affinity:     hub.Spec.Config.Affinity,
+				}
+			}
+			if diff := cmp.Diff(tc.config, hubConfig); diff != "" {
+				t.Errorf("unexpected v1 config (-want +got):\n%s", diff)
+			}
+
+			dst := &AWSLoadBalancerController{}
+			if err := dst.ConvertFrom(hub); err != nil {
+				t.Fatalf("failed to convert from v1: %v", err)
+			}
+			if diff := cmp.Diff(src, dst); diff != "" {
+				t.Errorf("unexpected round trip result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
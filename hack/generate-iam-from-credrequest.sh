@@ -15,4 +15,4 @@ cat <<EOF > "${POLICY_FILE}"
 }
 EOF
 ${YQ_BIN} -i -o=json "${POLICY_FILE}"
-sed -i -e 's/action/Action/g' -e 's/effect/Effect/g' -e 's/resource/Resource/g' "${POLICY_FILE}"
+sed -i -e 's/action/Action/g' -e 's/effect/Effect/g' -e 's/resource/Resource/g' -e 's/policyCondition/Condition/g' "${POLICY_FILE}"
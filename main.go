@@ -47,6 +47,8 @@ import (
 	metrics "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
+	elbv2v1beta1 "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+
 	networkingolmv1 "github.com/openshift/aws-load-balancer-operator/api/v1"
 	networkingolmv1alpha1 "github.com/openshift/aws-load-balancer-operator/api/v1alpha1"
 	"github.com/openshift/aws-load-balancer-operator/pkg/aws"
@@ -83,6 +85,7 @@ func init() {
 	utilruntime.Must(cco.Install(scheme))
 	utilruntime.Must(networkingv1.AddToScheme(scheme))
 	utilruntime.Must(arv1.AddToScheme(scheme))
+	utilruntime.Must(elbv2v1beta1.AddToScheme(scheme))
 }
 
 func main() {
@@ -92,6 +95,7 @@ func main() {
 		probeAddr              string
 		namespace              string
 		image                  string
+		operandVersion         string
 		trustedCAConfigMapName string
 		webhookDisableHTTP2    bool
 	)
@@ -102,6 +106,7 @@ func main() {
 			"Enabling this will ensure there is only one active controller manager.")
 	flag.StringVar(&namespace, "namespace", "aws-load-balancer-operator", "The namespace where operands should be installed")
 	flag.StringVar(&image, "image", "quay.io/aws-load-balancer-operator/aws-load-balancer-controller:latest", "The image to be used for the operand")
+	flag.StringVar(&operandVersion, "operand-version", "", "The version of the operand image. Inferred from the image tag if not set, the oldest supported version is assumed if the tag has no version.")
 	flag.StringVar(&trustedCAConfigMapName, "trusted-ca-configmap", "", "The name of the config map containing TLS CA(s) which should be trusted by the controller's containers. PEM encoded file under \"ca-bundle.crt\" key is expected.")
 	flag.BoolVar(&webhookDisableHTTP2, "webhook-disable-http2", false, "Disable HTTP/2 for the webhook server.")
 	opts := zap.Options{
@@ -112,6 +117,12 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	operandSemver, err := awsloadbalancercontroller.ParseOperandVersion(image, operandVersion)
+	if err != nil {
+		setupLog.Error(err, "invalid operand version")
+		os.Exit(1)
+	}
+
 	webhookSrv := webhook.NewServer(webhook.Options{
 		TLSOpts: []func(config *tls.Config){
 			func(config *tls.Config) {
@@ -165,15 +176,20 @@ func main() {
 		os.Exit(1)
 	}
 
-	// make and aws.EC2Client
-	ec2Client, err := aws.NewClient(context.TODO(), awsRegion, awsSharedCredFileName)
+	// make the provider of the aws.EC2Client and aws.ELBv2Client
+	// which reloads the clients when the operator credentials are rotated
+	clientProvider, err := operator.NewClientProvider(context.TODO(), mgr.GetClient(), namespace, awsRegion, awsSharedCredFileName)
 	if err != nil {
-		setupLog.Error(err, "failed to make aws client")
+		setupLog.Error(err, "failed to make aws clients")
+		os.Exit(1)
+	}
+	if err := clientProvider.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "operator-credentials")
 		os.Exit(1)
 	}
 
 	// get the VPC ID where the cluster is running
-	vpcID, err := getVPCId(context.TODO(), ec2Client, clusterName, awsRequestTimeout, awsRequestPollInterval)
+	vpcID, err := getVPCId(context.TODO(), clientProvider.EC2Client(), clusterName, awsRequestTimeout, awsRequestPollInterval)
 	if err != nil {
 		setupLog.Error(err, "failed to get VPC ID")
 		os.Exit(1)
@@ -182,13 +198,16 @@ func main() {
 	if err = (&awsloadbalancercontroller.AWSLoadBalancerControllerReconciler{
 		Client:                 mgr.GetClient(),
 		Scheme:                 mgr.GetScheme(),
-		EC2Client:              ec2Client,
+		ClientProvider:         clientProvider,
+		NewSTSClient:           aws.NewStaticSTSClient,
 		Namespace:              namespace,
 		Image:                  image,
+		OperandVersion:         operandSemver,
 		VPCID:                  vpcID,
 		ClusterName:            clusterName,
 		AWSRegion:              awsRegion,
 		TrustedCAConfigMapName: trustedCAConfigMapName,
+		Recorder:               mgr.GetEventRecorderFor("aws-load-balancer-operator"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AWSLoadBalancerController")
 		os.Exit(1)
@@ -27,20 +27,56 @@ type SubnetClient interface {
 	DeleteTags(context.Context, *ec2.DeleteTagsInput, ...func(*ec2.Options)) (*ec2.DeleteTagsOutput, error)
 }
 
-// EC2Client has a VPCClient and SubnetClient
+// RouteTableClient can be used to query route tables and internet gateways
+type RouteTableClient interface {
+	DescribeRouteTables(context.Context, *ec2.DescribeRouteTablesInput, ...func(*ec2.Options)) (*ec2.DescribeRouteTablesOutput, error)
+	DescribeInternetGateways(context.Context, *ec2.DescribeInternetGatewaysInput, ...func(*ec2.Options)) (*ec2.DescribeInternetGatewaysOutput, error)
+}
+
+// AvailabilityZoneClient can be used to query availability zones
+type AvailabilityZoneClient interface {
+	DescribeAvailabilityZones(context.Context, *ec2.DescribeAvailabilityZonesInput, ...func(*ec2.Options)) (*ec2.DescribeAvailabilityZonesOutput, error)
+}
+
+// SecurityGroupClient can be used to query and delete security groups
+type SecurityGroupClient interface {
+	DescribeSecurityGroups(context.Context, *ec2.DescribeSecurityGroupsInput, ...func(*ec2.Options)) (*ec2.DescribeSecurityGroupsOutput, error)
+	DeleteSecurityGroup(context.Context, *ec2.DeleteSecurityGroupInput, ...func(*ec2.Options)) (*ec2.DeleteSecurityGroupOutput, error)
+}
+
+// EC2Client has a VPCClient, SubnetClient, RouteTableClient, AvailabilityZoneClient and SecurityGroupClient
 type EC2Client interface {
 	VPCClient
 	SubnetClient
+	RouteTableClient
+	AvailabilityZoneClient
+	SecurityGroupClient
+}
+
+// ClientProvider provides the current AWS clients.
+// The clients can be replaced by the provider, for instance when the credentials are rotated.
+type ClientProvider interface {
+	EC2Client() EC2Client
+	ELBv2Client() ELBv2Client
+	IAMClient() IAMClient
 }
 
 func NewClient(ctx context.Context, awsRegion, sharedCredFileName string) (EC2Client, error) {
-	awsConfig, err := config.LoadDefaultConfig(ctx, config.WithRegion(awsRegion), config.WithSharedCredentialsFiles([]string{sharedCredFileName}))
+	awsConfig, err := loadConfig(ctx, awsRegion, sharedCredFileName)
 	if err != nil {
-		return nil, fmt.Errorf("unable to load AWS config: %w", err)
+		return nil, err
 	}
 	return ec2.NewFromConfig(awsConfig), nil
 }
 
+func loadConfig(ctx context.Context, awsRegion, sharedCredFileName string) (aws.Config, error) {
+	awsConfig, err := config.LoadDefaultConfig(ctx, config.WithRegion(awsRegion), config.WithSharedCredentialsFiles([]string{sharedCredFileName}))
+	if err != nil {
+		return aws.Config{}, fmt.Errorf("unable to load AWS config: %w", err)
+	}
+	return awsConfig, nil
+}
+
 // GetVPCId return the VPC ID of the cluster
 func GetVPCId(ctx context.Context, ec2Client EC2Client, clusterName string) (string, error) {
 	infraTagKey := fmt.Sprintf(clusterTagKey, clusterName)
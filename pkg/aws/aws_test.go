@@ -12,6 +12,9 @@ import (
 
 type testEC2Client struct {
 	SubnetClient
+	RouteTableClient
+	AvailabilityZoneClient
+	SecurityGroupClient
 	t           *testing.T
 	clusterName string
 	output      []string
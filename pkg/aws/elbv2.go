@@ -0,0 +1,138 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	elbv2 "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+)
+
+const (
+	// elbv2ClusterTagKey is the tag put by the aws-load-balancer-controller on the resources it provisions for a cluster.
+	elbv2ClusterTagKey = "elbv2.k8s.aws/cluster"
+	// describeTagsMaxResources is the maximum number of resources accepted by a single DescribeTags call.
+	describeTagsMaxResources = 20
+)
+
+// ELBv2ReadOnlyClient can be used to query load balancers, target groups and their tags
+type ELBv2ReadOnlyClient interface {
+	DescribeLoadBalancers(context.Context, *elbv2.DescribeLoadBalancersInput, ...func(*elbv2.Options)) (*elbv2.DescribeLoadBalancersOutput, error)
+	DescribeTargetGroups(context.Context, *elbv2.DescribeTargetGroupsInput, ...func(*elbv2.Options)) (*elbv2.DescribeTargetGroupsOutput, error)
+	DescribeTags(context.Context, *elbv2.DescribeTagsInput, ...func(*elbv2.Options)) (*elbv2.DescribeTagsOutput, error)
+}
+
+// ELBv2DeleteClient can be used to delete load balancers and target groups
+type ELBv2DeleteClient interface {
+	DeleteLoadBalancer(context.Context, *elbv2.DeleteLoadBalancerInput, ...func(*elbv2.Options)) (*elbv2.DeleteLoadBalancerOutput, error)
+	DeleteTargetGroup(context.Context, *elbv2.DeleteTargetGroupInput, ...func(*elbv2.Options)) (*elbv2.DeleteTargetGroupOutput, error)
+}
+
+// ELBv2Client has an ELBv2ReadOnlyClient and ELBv2DeleteClient
+type ELBv2Client interface {
+	ELBv2ReadOnlyClient
+	ELBv2DeleteClient
+}
+
+// NewELBv2Client returns a client of the Elastic Load Balancing v2 API.
+func NewELBv2Client(ctx context.Context, awsRegion, sharedCredFileName string) (ELBv2Client, error) {
+	awsConfig, err := loadConfig(ctx, awsRegion, sharedCredFileName)
+	if err != nil {
+		return nil, err
+	}
+	return elbv2.NewFromConfig(awsConfig), nil
+}
+
+// LoadBalancer is a load balancer along with its tags.
+type LoadBalancer struct {
+	elbv2types.LoadBalancer
+	Tags map[string]string
+}
+
+// GetClusterLoadBalancers returns the load balancers of the given VPC which were provisioned
+// by the aws-load-balancer-controller for the given cluster.
+func GetClusterLoadBalancers(ctx context.Context, client ELBv2ReadOnlyClient, clusterName, vpcID string) ([]LoadBalancer, error) {
+	var (
+		arns          []string
+		loadBalancers = map[string]elbv2types.LoadBalancer{}
+	)
+	paginator := elbv2.NewDescribeLoadBalancersPaginator(client, &elbv2.DescribeLoadBalancersInput{})
+	for paginator.HasMorePages() {
+		response, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list load balancers: %w", err)
+		}
+		for _, lb := range response.LoadBalancers {
+			if aws.ToString(lb.VpcId) == vpcID {
+				arns = append(arns, aws.ToString(lb.LoadBalancerArn))
+				loadBalancers[aws.ToString(lb.LoadBalancerArn)] = lb
+			}
+		}
+	}
+
+	tags, err := describeClusterTags(ctx, client, clusterName, arns)
+	if err != nil {
+		return nil, err
+	}
+	var clusterLoadBalancers []LoadBalancer
+	for _, arn := range arns {
+		if lbTags, tagged := tags[arn]; tagged {
+			clusterLoadBalancers = append(clusterLoadBalancers, LoadBalancer{LoadBalancer: loadBalancers[arn], Tags: lbTags})
+		}
+	}
+	return clusterLoadBalancers, nil
+}
+
+// GetClusterTargetGroups returns the ARNs of the target groups of the given VPC which were provisioned
+// by the aws-load-balancer-controller for the given cluster.
+func GetClusterTargetGroups(ctx context.Context, client ELBv2ReadOnlyClient, clusterName, vpcID string) ([]string, error) {
+	var arns []string
+	paginator := elbv2.NewDescribeTargetGroupsPaginator(client, &elbv2.DescribeTargetGroupsInput{})
+	for paginator.HasMorePages() {
+		response, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list target groups: %w", err)
+		}
+		for _, tg := range response.TargetGroups {
+			if aws.ToString(tg.VpcId) == vpcID {
+				arns = append(arns, aws.ToString(tg.TargetGroupArn))
+			}
+		}
+	}
+
+	tags, err := describeClusterTags(ctx, client, clusterName, arns)
+	if err != nil {
+		return nil, err
+	}
+	var clusterTargetGroups []string
+	for _, arn := range arns {
+		if _, tagged := tags[arn]; tagged {
+			clusterTargetGroups = append(clusterTargetGroups, arn)
+		}
+	}
+	return clusterTargetGroups, nil
+}
+
+// describeClusterTags returns the tags of the given resources which are tagged with the cluster tag
+// of the aws-load-balancer-controller, the resources of the other clusters are left out.
+func describeClusterTags(ctx context.Context, client ELBv2ReadOnlyClient, clusterName string, arns []string) (map[string]map[string]string, error) {
+	tags := map[string]map[string]string{}
+	for start := 0; start < len(arns); start += describeTagsMaxResources {
+		end := min(start+describeTagsMaxResources, len(arns))
+		response, err := client.DescribeTags(ctx, &elbv2.DescribeTagsInput{ResourceArns: arns[start:end]})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe tags of %v: %w", arns[start:end], err)
+		}
+		for _, description := range response.TagDescriptions {
+			resourceTags := map[string]string{}
+			for _, tag := range description.Tags {
+				resourceTags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+			}
+			if resourceTags[elbv2ClusterTagKey] == clusterName {
+				tags[aws.ToString(description.ResourceArn)] = resourceTags
+			}
+		}
+	}
+	return tags, nil
+}
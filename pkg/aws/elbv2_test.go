@@ -0,0 +1,82 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	elbv2 "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+type testELBv2Client struct {
+	ELBv2ReadOnlyClient
+	t             *testing.T
+	loadBalancers []elbv2types.LoadBalancer
+	// tags are the tags of each resource
+	tags map[string]map[string]string
+}
+
+func (c *testELBv2Client) DescribeLoadBalancers(_ context.Context, _ *elbv2.DescribeLoadBalancersInput, _ ...func(*elbv2.Options)) (*elbv2.DescribeLoadBalancersOutput, error) {
+	return &elbv2.DescribeLoadBalancersOutput{LoadBalancers: c.loadBalancers}, nil
+}
+
+func (c *testELBv2Client) DescribeTags(_ context.Context, input *elbv2.DescribeTagsInput, _ ...func(*elbv2.Options)) (*elbv2.DescribeTagsOutput, error) {
+	if len(input.ResourceArns) > describeTagsMaxResources {
+		c.t.Fatalf("too many resources in DescribeTags query: %d", len(input.ResourceArns))
+	}
+	output := &elbv2.DescribeTagsOutput{}
+	for _, arn := range input.ResourceArns {
+		description := elbv2types.TagDescription{ResourceArn: aws.String(arn)}
+		for key, value := range c.tags[arn] {
+			description.Tags = append(description.Tags, elbv2types.Tag{Key: aws.String(key), Value: aws.String(value)})
+		}
+		output.TagDescriptions = append(output.TagDescriptions, description)
+	}
+	return output, nil
+}
+
+func TestGetClusterLoadBalancers(t *testing.T) {
+	var (
+		loadBalancers []elbv2types.LoadBalancer
+		tags          = map[string]map[string]string{}
+		expectedARNs  []string
+	)
+	// more load balancers than a single DescribeTags call accepts
+	for i := 0; i < 2*describeTagsMaxResources+5; i++ {
+		arn := fmt.Sprintf("lb-%d", i)
+		vpcID := "test-vpc"
+		clusterName := "test-cluster"
+		switch i % 5 {
+		case 1:
+			vpcID = "other-vpc"
+		case 2:
+			clusterName = "other-cluster"
+		}
+		loadBalancers = append(loadBalancers, elbv2types.LoadBalancer{LoadBalancerArn: aws.String(arn), VpcId: aws.String(vpcID)})
+		if i%5 == 3 {
+			continue
+		}
+		tags[arn] = map[string]string{elbv2ClusterTagKey: clusterName, "ingress.k8s.aws/stack": "test-group"}
+		if vpcID == "test-vpc" && clusterName == "test-cluster" {
+			expectedARNs = append(expectedARNs, arn)
+		}
+	}
+
+	clusterLoadBalancers, err := GetClusterLoadBalancers(context.Background(), &testELBv2Client{t: t, loadBalancers: loadBalancers, tags: tags}, "test-cluster", "test-vpc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var arns []string
+	for _, lb := range clusterLoadBalancers {
+		arns = append(arns, aws.ToString(lb.LoadBalancerArn))
+		if lb.Tags["ingress.k8s.aws/stack"] != "test-group" {
+			t.Errorf("expected tags of %s to be returned, got %v", aws.ToString(lb.LoadBalancerArn), lb.Tags)
+		}
+	}
+	if diff := cmp.Diff(expectedARNs, arns); diff != "" {
+		t.Errorf("unexpected load balancers (-want +got):\n%s", diff)
+	}
+}
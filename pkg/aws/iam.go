@@ -0,0 +1,103 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// IAMClient can be used to simulate the IAM policies of a principal
+type IAMClient interface {
+	SimulatePrincipalPolicy(context.Context, *iam.SimulatePrincipalPolicyInput, ...func(*iam.Options)) (*iam.SimulatePrincipalPolicyOutput, error)
+	GetRole(context.Context, *iam.GetRoleInput, ...func(*iam.Options)) (*iam.GetRoleOutput, error)
+}
+
+// STSClient can be used to get the identity of the credentials
+type STSClient interface {
+	GetCallerIdentity(context.Context, *sts.GetCallerIdentityInput, ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error)
+}
+
+// NewIAMClient returns a client of the IAM API.
+func NewIAMClient(ctx context.Context, awsRegion, sharedCredFileName string) (IAMClient, error) {
+	awsConfig, err := loadConfig(ctx, awsRegion, sharedCredFileName)
+	if err != nil {
+		return nil, err
+	}
+	return iam.NewFromConfig(awsConfig), nil
+}
+
+// NewStaticSTSClient returns a client of the STS API which uses the given static credentials.
+func NewStaticSTSClient(ctx context.Context, awsRegion, accessKeyID, secretAccessKey string) (STSClient, error) {
+	awsConfig, err := config.LoadDefaultConfig(ctx, config.WithRegion(awsRegion),
+		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(accessKeyID, secretAccessKey, "")))
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS config: %w", err)
+	}
+	return sts.NewFromConfig(awsConfig), nil
+}
+
+// GetPrincipalARN returns the ARN of the IAM user or role of the credentials used by the given STS client.
+// The role of an assumed role session is looked up with the given IAM client
+// as the session ARN doesn't have the path of the role.
+func GetPrincipalARN(ctx context.Context, client STSClient, iamClient IAMClient) (string, error) {
+	identity, err := client.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get caller identity: %w", err)
+	}
+	if identity.Arn == nil {
+		return "", fmt.Errorf("caller identity has no ARN")
+	}
+	callerARN, err := arn.Parse(*identity.Arn)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse caller identity ARN %q: %w", *identity.Arn, err)
+	}
+	// arn:aws:sts::123456789012:assumed-role/role-name/session-name
+	if callerARN.Service == "sts" && strings.HasPrefix(callerARN.Resource, "assumed-role/") {
+		parts := strings.Split(callerARN.Resource, "/")
+		if len(parts) < 2 {
+			return "", fmt.Errorf("unexpected assumed role ARN %q", *identity.Arn)
+		}
+		output, err := iamClient.GetRole(ctx, &iam.GetRoleInput{RoleName: aws.String(parts[1])})
+		if err != nil {
+			return "", fmt.Errorf("failed to get role %q: %w", parts[1], err)
+		}
+		if output.Role == nil || output.Role.Arn == nil {
+			return "", fmt.Errorf("role %q has no ARN", parts[1])
+		}
+		return *output.Role.Arn, nil
+	}
+	return callerARN.String(), nil
+}
+
+// GetDeniedActions returns the sorted actions which the given principal is not allowed to perform on the given resources.
+// The actions allowed only with some conditions are considered allowed as the simulation doesn't provide the context values.
+func GetDeniedActions(ctx context.Context, client IAMClient, principalARN string, actions, resources []string) ([]string, error) {
+	paginator := iam.NewSimulatePrincipalPolicyPaginator(client, &iam.SimulatePrincipalPolicyInput{
+		PolicySourceArn: aws.String(principalARN),
+		ActionNames:     actions,
+		ResourceArns:    resources,
+	})
+	var denied []string
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range output.EvaluationResults {
+			if result.EvalDecision != iamtypes.PolicyEvaluationDecisionTypeAllowed && len(result.MissingContextValues) == 0 {
+				denied = append(denied, aws.ToString(result.EvalActionName))
+			}
+		}
+	}
+	sort.Strings(denied)
+	return denied, nil
+}
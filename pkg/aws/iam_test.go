@@ -0,0 +1,152 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/google/go-cmp/cmp"
+)
+
+type testIAMClient struct {
+	// decisions are the decisions of the simulation of each action, "allowed" if not set
+	decisions map[string]iamtypes.PolicyEvaluationDecisionType
+	// conditional are the actions allowed only with some condition keys
+	conditional map[string]bool
+	// pageSize is the maximum number of evaluation results of a page
+	pageSize int
+	// roles are the ARNs of the roles by name
+	roles map[string]string
+}
+
+func (c *testIAMClient) SimulatePrincipalPolicy(_ context.Context, input *iam.SimulatePrincipalPolicyInput, _ ...func(*iam.Options)) (*iam.SimulatePrincipalPolicyOutput, error) {
+	start := 0
+	if input.Marker != nil {
+		if _, err := fmt.Sscanf(*input.Marker, "%d", &start); err != nil {
+			return nil, err
+		}
+	}
+	end := min(start+c.pageSize, len(input.ActionNames))
+	output := &iam.SimulatePrincipalPolicyOutput{}
+	for _, action := range input.ActionNames[start:end] {
+		result := iamtypes.EvaluationResult{EvalActionName: aws.String(action), EvalDecision: iamtypes.PolicyEvaluationDecisionTypeAllowed}
+		if decision, found := c.decisions[action]; found {
+			result.EvalDecision = decision
+		}
+		if c.conditional[action] {
+			result.MissingContextValues = []string{"aws:ResourceTag/elbv2.k8s.aws/cluster"}
+		}
+		output.EvaluationResults = append(output.EvaluationResults, result)
+	}
+	if end < len(input.ActionNames) {
+		output.IsTruncated = true
+		output.Marker = aws.String(fmt.Sprintf("%d", end))
+	}
+	return output, nil
+}
+
+func (c *testIAMClient) GetRole(_ context.Context, input *iam.GetRoleInput, _ ...func(*iam.Options)) (*iam.GetRoleOutput, error) {
+	roleARN, found := c.roles[aws.ToString(input.RoleName)]
+	if !found {
+		return nil, fmt.Errorf("role %q not found", aws.ToString(input.RoleName))
+	}
+	return &iam.GetRoleOutput{Role: &iamtypes.Role{Arn: aws.String(roleARN)}}, nil
+}
+
+type testSTSClient struct {
+	arn string
+}
+
+func (c *testSTSClient) GetCallerIdentity(_ context.Context, _ *sts.GetCallerIdentityInput, _ ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error) {
+	return &sts.GetCallerIdentityOutput{Arn: aws.String(c.arn)}, nil
+}
+
+func TestGetDeniedActions(t *testing.T) {
+	actions := []string{
+		"ec2:DescribeVpcs",
+		"ec2:DescribeSubnets",
+		"ec2:CreateTags",
+		"elasticloadbalancing:AddTags",
+		"elasticloadbalancing:CreateLoadBalancer",
+	}
+	client := &testIAMClient{
+		decisions: map[string]iamtypes.PolicyEvaluationDecisionType{
+			"elasticloadbalancing:AddTags":            iamtypes.PolicyEvaluationDecisionTypeImplicitDeny,
+			"ec2:DescribeSubnets":                     iamtypes.PolicyEvaluationDecisionTypeExplicitDeny,
+			"ec2:CreateTags":                          iamtypes.PolicyEvaluationDecisionTypeImplicitDeny,
+			"elasticloadbalancing:CreateLoadBalancer": iamtypes.PolicyEvaluationDecisionTypeImplicitDeny,
+		},
+		conditional: map[string]bool{
+			"ec2:CreateTags": true,
+		},
+		pageSize: 2,
+	}
+
+	denied, err := GetDeniedActions(context.Background(), client, "arn:aws:iam::123456789012:role/test", actions, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{"ec2:DescribeSubnets", "elasticloadbalancing:AddTags", "elasticloadbalancing:CreateLoadBalancer"}
+	if diff := cmp.Diff(expected, denied); diff != "" {
+		t.Errorf("unexpected denied actions (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetPrincipalARN(t *testing.T) {
+	tests := []struct {
+		name        string
+		callerARN   string
+		expected    string
+		errExpected bool
+	}{
+		{
+			name:      "user",
+			callerARN: "arn:aws:iam::123456789012:user/test-user",
+			expected:  "arn:aws:iam::123456789012:user/test-user",
+		},
+		{
+			name:      "assumed role",
+			callerARN: "arn:aws-us-gov:sts::123456789012:assumed-role/test-role/test-session",
+			expected:  "arn:aws-us-gov:iam::123456789012:role/test-role",
+		},
+		{
+			name:      "assumed role with path",
+			callerARN: "arn:aws:sts::123456789012:assumed-role/test-path-role/test-session",
+			expected:  "arn:aws:iam::123456789012:role/test/path/test-path-role",
+		},
+		{
+			name:        "unknown assumed role",
+			callerARN:   "arn:aws:sts::123456789012:assumed-role/unknown-role/test-session",
+			errExpected: true,
+		},
+		{
+			name:        "invalid ARN",
+			callerARN:   "test-user",
+			errExpected: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			iamClient := &testIAMClient{
+				roles: map[string]string{
+					"test-role":      "arn:aws-us-gov:iam::123456789012:role/test-role",
+					"test-path-role": "arn:aws:iam::123456789012:role/test/path/test-path-role",
+				},
+			}
+			principal, err := GetPrincipalARN(context.Background(), &testSTSClient{arn: tc.callerARN}, iamClient)
+			if err != nil && !tc.errExpected {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err == nil && tc.errExpected {
+				t.Fatalf("error expected but not received")
+			}
+			if principal != tc.expected {
+				t.Errorf("unexpected principal, expected %q, got %q", tc.expected, principal)
+			}
+		})
+	}
+}
@@ -24,10 +24,16 @@ import (
 	arv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/client-go/tools/record"
 
 	cco "github.com/openshift/cloud-credential-operator/pkg/apis/cloudcredential/v1"
 
@@ -40,6 +46,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	elbv2v1beta1 "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+
 	albo "github.com/openshift/aws-load-balancer-operator/api/v1"
 	"github.com/openshift/aws-load-balancer-operator/pkg/aws"
 )
@@ -62,14 +70,37 @@ const (
 // AWSLoadBalancerControllerReconciler reconciles a AWSLoadBalancerController object
 type AWSLoadBalancerControllerReconciler struct {
 	client.Client
-	Scheme                 *runtime.Scheme
-	Namespace              string
-	Image                  string
-	EC2Client              aws.EC2Client
+	Scheme         *runtime.Scheme
+	Namespace      string
+	Image          string
+	OperandVersion *version.Version
+	EC2Client      aws.EC2Client
+	ELBv2Client    aws.ELBv2Client
+	IAMClient      aws.IAMClient
+	// NewSTSClient builds the client used to get the identity of the static controller credentials.
+	NewSTSClient func(ctx context.Context, awsRegion, accessKeyID, secretAccessKey string) (aws.STSClient, error)
+	// ClientProvider provides the up to date EC2Client, ELBv2Client and IAMClient at every reconciliation if set.
+	ClientProvider         aws.ClientProvider
 	ClusterName            string
 	VPCID                  string
 	AWSRegion              string
 	TrustedCAConfigMapName string
+	Recorder               record.EventRecorder
+
+	// subnetsSyncedAt is the time of the last subnet resync
+	subnetsSyncedAt time.Time
+	// subnetsSyncedHash is the hash of the subnet spec of the last subnet resync
+	subnetsSyncedHash string
+	// loadBalancersSyncedAt is the time of the last load balancer inventory
+	loadBalancersSyncedAt time.Time
+	// loadBalancersSyncFailed is true if the last load balancer inventory failed
+	loadBalancersSyncFailed bool
+	// permissionsCheckedAt is the time of the last check of the controller credentials permissions
+	permissionsCheckedAt time.Time
+	// permissionsCheckedHash is the hash of the controller credentials checked last
+	permissionsCheckedHash string
+	// permissionsCheckFailed is true if the last check of the controller credentials permissions failed
+	permissionsCheckFailed bool
 }
 
 //+kubebuilder:rbac:groups=networking.olm.openshift.io,resources=awsloadbalancercontrollers,verbs=get;list;watch;create;update;patch;delete
@@ -78,18 +109,28 @@ type AWSLoadBalancerControllerReconciler struct {
 //+kubebuilder:rbac:groups="",resources=services;secrets,namespace=system,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=configmaps,namespace=system,verbs=get;list;watch
 //+kubebuilder:rbac:groups="networking.k8s.io",resources=ingressclasses,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="elbv2.k8s.aws",resources=ingressclassparams,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="config.openshift.io",resources=infrastructures,verbs=get;list;watch
 //+kubebuilder:rbac:groups="apps",resources=deployments,namespace=system,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="policy",resources=poddisruptionbudgets,namespace=system,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=serviceaccounts,namespace=system,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=rbac.authorization.k8s.io,namespace=system,resources=roles;rolebindings,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterrolebindings,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=bind;get,resourceNames=aws-load-balancer-operator-controller-role
 //+kubebuilder:rbac:groups=cloudcredential.openshift.io,resources=credentialsrequests;credentialsrequests/status;credentialsrequests/finalizers,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=validatingwebhookconfigurations;mutatingwebhookconfigurations,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *AWSLoadBalancerControllerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
+	// the clients are replaced when the operator credentials are rotated
+	if r.ClientProvider != nil {
+		r.EC2Client = r.ClientProvider.EC2Client()
+		r.ELBv2Client = r.ClientProvider.ELBv2Client()
+		r.IAMClient = r.ClientProvider.IAMClient()
+	}
+
 	lbController, exists, err := r.getAWSLoadBalancerController(ctx, req.Name)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to get AWSLoadBalancerController %q: %w", req.Name, err)
@@ -99,23 +140,35 @@ func (r *AWSLoadBalancerControllerReconciler) Reconcile(ctx context.Context, req
 	}
 
 	if lbController.DeletionTimestamp != nil {
-		logger.Info("AWSLoadBalancerController is going to be deleted. Skipping Reconcile")
-		return ctrl.Result{}, nil
+		logger.Info("AWSLoadBalancerController is going to be deleted. Cleaning up")
+		return r.finalize(ctx, lbController)
+	}
 
+	if err := r.ensureFinalizer(ctx, lbController); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to add finalizer to AWSLoadBalancerController %q: %w", req.Name, err)
 	}
 
 	servingSecretName := fmt.Sprintf("%s-serving-%s", controllerResourcePrefix, lbController.Name)
 
-	// if the processed subnets have not yet been written into the status or if the tagging policy has changed then update the subnets
-	if lbController.Status.Subnets == nil || (lbController.Spec.SubnetTagging != lbController.Status.Subnets.SubnetTagging) {
-		internalSubnets, publicSubnets, untaggedSubnets, taggedSubnets, err := r.tagSubnets(ctx, lbController)
-		if err != nil {
-			return ctrl.Result{}, fmt.Errorf("failed to update subnets: %w", err)
-		}
-		err = r.updateStatusSubnets(ctx, lbController, internalSubnets, publicSubnets, untaggedSubnets, taggedSubnets, lbController.Spec.SubnetTagging)
+	// the subnets are rediscovered periodically to tag the subnets added to the cluster
+	subnetsSynced, nextSubnetSync, err := r.syncSubnets(ctx, lbController)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to sync subnets of AWSLoadBalancerController %q: %w", req.Name, err)
+	}
+	if subnetsSynced {
+		// reload the resource after updating the status
+		lbController, _, err = r.getAWSLoadBalancerController(ctx, req.Name)
 		if err != nil {
-			return ctrl.Result{}, fmt.Errorf("failed to update AWSLoadBalancerController %q status with subnets: %w", req.Name, err)
+			return ctrl.Result{}, fmt.Errorf("failed to get AWSLoadBalancerController %q: %w", req.Name, err)
 		}
+	}
+
+	// the load balancers provisioned by the controller are listed periodically
+	loadBalancersSynced, nextLoadBalancerSync, err := r.syncLoadBalancers(ctx, lbController)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to sync load balancers of AWSLoadBalancerController %q: %w", req.Name, err)
+	}
+	if loadBalancersSynced {
 		// reload the resource after updating the status
 		lbController, _, err = r.getAWSLoadBalancerController(ctx, req.Name)
 		if err != nil {
@@ -129,12 +182,18 @@ func (r *AWSLoadBalancerControllerReconciler) Reconcile(ctx context.Context, req
 	}
 	platformStatus := infraConfig.Status.PlatformStatus
 
-	if err := r.ensureIngressClass(ctx, lbController); err != nil {
+	conflictingIngressClasses, conflictingDefaultIngressClasses, conflictingIngressClassParams, err := r.ensureIngressClass(ctx, lbController)
+	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to ensure default IngressClass for AWSLoadBalancerController %q: %v", req.Name, err)
 	}
-	// if the ingress class in the status differs from what's in the spec update it
-	if lbController.Spec.IngressClass != lbController.Status.IngressClass {
-		err = r.updateStatusIngressClass(ctx, lbController, lbController.Spec.IngressClass)
+	ingressClassConds := append(ingressClassConditions(conflictingIngressClasses, conflictingIngressClassParams, lbController.Generation), defaultIngressClassConditions(lbController, conflictingDefaultIngressClasses)...)
+	if err := r.updateStatusConditions(ctx, lbController, ingressClassConds...); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update IngressClass conditions of AWSLoadBalancerController %q: %w", req.Name, err)
+	}
+	// if the ingress classes in the status differ from what's in the spec update them
+	ingressClasses := ingressClassNames(lbController)
+	if lbController.Spec.IngressClass != lbController.Status.IngressClass || !equality.Semantic.DeepEqual(ingressClasses, lbController.Status.IngressClasses) {
+		err = r.updateStatusIngressClass(ctx, lbController, lbController.Spec.IngressClass, ingressClasses)
 		if err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed to update IngressClass in AWSLoadBalancerController %q Status: %w", req.Name, err)
 		}
@@ -146,7 +205,14 @@ func (r *AWSLoadBalancerControllerReconciler) Reconcile(ctx context.Context, req
 	}
 
 	credSecretNsName := types.NamespacedName{Namespace: r.Namespace}
-	if lbController.Spec.Credentials == nil {
+	if webIdentityCredentialsEnabled(lbController) {
+		// the operator provisions the credentials itself, the Cloud Credential Operator is not needed
+		credentialsSecret, err := r.ensureWebIdentityCredentialsSecret(ctx, r.Namespace, lbController)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to ensure web identity credentials secret for AWSLoadBalancerController %q: %w", req.Name, err)
+		}
+		credSecretNsName.Name = credentialsSecret.Name
+	} else if lbController.Spec.Credentials == nil {
 		credentialsRequest, err := r.ensureCredentialsRequest(ctx, r.Namespace, lbController)
 		if err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed to ensure CredentialsRequest for AWSLoadBalancerController %q: %w", req.Name, err)
@@ -156,13 +222,19 @@ func (r *AWSLoadBalancerControllerReconciler) Reconcile(ctx context.Context, req
 		credSecretNsName.Name = lbController.Spec.Credentials.Name
 	}
 
-	secretProvisioned, err := r.credentialsSecretProvisioned(ctx, credSecretNsName)
+	credSecret, secretProvisioned, err := r.getCredentialsSecret(ctx, credSecretNsName)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to verify credentials secret %q for AWSLoadBalancerController %q has been provisioned: %w", credSecretNsName.Name, req.Name, err)
 	}
 
+	// the user provided credentials are validated before being rolled out to the controller
+	var credSecretErr error
+	if secretProvisioned && lbController.Spec.Credentials != nil {
+		credSecretErr = validateCredentialsSecret(credSecret)
+	}
+
 	// updating CR status
-	if err := r.updateControllerStatus(ctx, lbController, nil, credSecretNsName.Name, secretProvisioned); err != nil {
+	if err := r.updateControllerStatus(ctx, lbController, nil, credSecretNsName.Name, secretProvisioned, credSecretErr); err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to update status of AWSLoadBalancerController %q: %w", req.Name, err)
 	}
 
@@ -173,6 +245,18 @@ func (r *AWSLoadBalancerControllerReconciler) Reconcile(ctx context.Context, req
 		return ctrl.Result{RequeueAfter: secretMissingReEnqueueDuration}, nil
 	}
 
+	// the changes of the credentials secret are watched, no need to re-enqueue
+	if credSecretErr != nil {
+		logger.Info("invalid credentials secret, the controller is not updated", "secret", credSecretNsName.Name, "reason", credSecretErr)
+		return ctrl.Result{}, nil
+	}
+
+	// the permissions of the credentials are only reported, the controller is rolled out anyway
+	nextPermissionsCheck, err := r.checkCredentialsPermissions(ctx, lbController, credSecret)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to check credentials permissions of AWSLoadBalancerController %q: %w", req.Name, err)
+	}
+
 	var trustCAConfigMap *corev1.ConfigMap
 	if r.TrustedCAConfigMapName != "" {
 		configMap, configMapExists, err := r.getConfigMap(ctx, r.TrustedCAConfigMapName, r.Namespace)
@@ -196,10 +280,29 @@ func (r *AWSLoadBalancerControllerReconciler) Reconcile(ctx context.Context, req
 		return ctrl.Result{}, fmt.Errorf("failed to ensure ClusterRole and Binding for AWSLoadBalancerController %q: %w", req.Name, err)
 	}
 
-	deployment, err := r.ensureDeployment(ctx, sa, credSecretNsName.Name, servingSecretName, lbController, platformStatus, trustCAConfigMap)
+	if err := r.updateStatusConditions(ctx, lbController, r.featureGatesConditions(lbController)...); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update feature gates condition of AWSLoadBalancerController %q: %w", req.Name, err)
+	}
+
+	deployment, credentialsRotated, err := r.ensureDeployment(ctx, sa, credSecret, servingSecretName, lbController, platformStatus, trustCAConfigMap)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to ensure Deployment for AWSLoadbalancerController %q: %w", req.Name, err)
 	}
+	if credentialsRotated {
+		logger.Info("rolling out the controller with the rotated credentials", "secret", credSecretNsName.Name)
+		if err := r.updateStatusCredentialsRotationTime(ctx, lbController, metav1.Now()); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update credentials rotation time of AWSLoadBalancerController %q: %w", req.Name, err)
+		}
+		// reload the resource after updating the status
+		lbController, _, err = r.getAWSLoadBalancerController(ctx, req.Name)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to get AWSLoadBalancerController %q: %w", req.Name, err)
+		}
+	}
+
+	if err := r.ensurePodDisruptionBudget(ctx, r.Namespace, lbController, deployment); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to ensure PodDisruptionBudget for AWSLoadBalancerController %q: %w", req.Name, err)
+	}
 
 	service, err := r.ensureService(ctx, r.Namespace, lbController, servingSecretName, deployment)
 	if err != nil {
@@ -211,10 +314,11 @@ func (r *AWSLoadBalancerControllerReconciler) Reconcile(ctx context.Context, req
 		return ctrl.Result{}, fmt.Errorf("failed to ensure webhooks for AWSLoadBalancerController %q: %w", req.Name, err)
 	}
 
-	if err := r.updateControllerStatus(ctx, lbController, deployment, credSecretNsName.Name, secretProvisioned); err != nil {
+	if err := r.updateControllerStatus(ctx, lbController, deployment, credSecretNsName.Name, secretProvisioned, nil); err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to update status of AWSLoadBalancerController %q: %w", req.Name, err)
 	}
-	return ctrl.Result{}, nil
+	// requeue for the next subnet resync, load balancer inventory or permissions check
+	return ctrl.Result{RequeueAfter: min(nextSubnetSync, nextLoadBalancerSync, nextPermissionsCheck)}, nil
 }
 
 func (r *AWSLoadBalancerControllerReconciler) getAWSLoadBalancerController(ctx context.Context, name string) (*albo.AWSLoadBalancerController, bool, error) {
@@ -252,9 +356,11 @@ func (r *AWSLoadBalancerControllerReconciler) BuildManagedController(mgr ctrl.Ma
 		Owns(&rbacv1.Role{}).
 		Owns(&rbacv1.RoleBinding{}).
 		Owns(&appsv1.Deployment{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
 		Owns(&corev1.Service{}).
 		Owns(&arv1.ValidatingWebhookConfiguration{}).
-		Owns(&arv1.MutatingWebhookConfiguration{})
+		Owns(&arv1.MutatingWebhookConfiguration{}).
+		Owns(&elbv2v1beta1.IngressClassParams{})
 
 	clusterALBCInstance := func(ctx context.Context, o client.Object) []reconcile.Request {
 		return []reconcile.Request{
@@ -279,6 +385,25 @@ func (r *AWSLoadBalancerControllerReconciler) BuildManagedController(mgr ctrl.Ma
 				predicate.NewPredicateFuncs(inNamespace(r.Namespace))),
 				predicate.NewPredicateFuncs(hasName(r.TrustedCAConfigMapName))))
 	}
+	// Watch all IngressClasses to repair the drift of the operator managed IngressClasses
+	// and to detect the removal of the IngressClasses which belong to another controller.
+	bldr = bldr.Watches(&networkingv1.IngressClass{},
+		handler.EnqueueRequestsFromMapFunc(clusterALBCInstance))
+	// Watch the credentials secret to roll out the controller when the credentials are rotated.
+	// The credentials secret is either provided by the user or provisioned by the CredentialsRequest.
+	credentialsSecretOwner := func(ctx context.Context, o client.Object) []reconcile.Request {
+		var controller albo.AWSLoadBalancerController
+		if err := mgr.GetClient().Get(ctx, types.NamespacedName{Name: controllerName}, &controller); err != nil {
+			return nil
+		}
+		if o.GetName() != credentialsSecretName(&controller) {
+			return nil
+		}
+		return clusterALBCInstance(ctx, o)
+	}
+	bldr = bldr.Watches(&corev1.Secret{},
+		handler.EnqueueRequestsFromMapFunc(credentialsSecretOwner),
+		builder.WithPredicates(predicate.NewPredicateFuncs(inNamespace(r.Namespace))))
 	// Watch Infrastructure object to detect changes in AWS user tags
 	bldr = bldr.Watches(&configv1.Infrastructure{},
 		handler.EnqueueRequestsFromMapFunc(clusterALBCInstance),
@@ -49,7 +49,7 @@ func (r *AWSLoadBalancerControllerReconciler) ensureCredentialsRequest(ctx conte
 		return nil, fmt.Errorf("failed to get existing credentials request %q: %w", credReq.Name, err)
 	}
 
-	credentialRequestSecretName := fmt.Sprintf("%s-credentialsrequest-%s", controllerResourcePrefix, controller.Name)
+	credentialRequestSecretName := credentialsRequestSecretName(controller)
 
 	// The secret created will be in the operator namespace.
 	secretRef := createCredentialsSecretRef(credentialRequestSecretName, namespace)
@@ -95,18 +95,37 @@ func (r *AWSLoadBalancerControllerReconciler) ensureCredentialsRequest(ctx conte
 	return current, nil
 }
 
-func (r *AWSLoadBalancerControllerReconciler) credentialsSecretProvisioned(ctx context.Context, name types.NamespacedName) (bool, error) {
+// getCredentialsSecret returns the secret with the AWS credentials of the controller and true if it exists.
+func (r *AWSLoadBalancerControllerReconciler) getCredentialsSecret(ctx context.Context, name types.NamespacedName) (*corev1.Secret, bool, error) {
 	var secret corev1.Secret
 
-	err := r.Client.Get(context.TODO(), name, &secret)
+	err := r.Client.Get(ctx, name, &secret)
 	if err != nil && errors.IsNotFound(err) {
 		log.FromContext(ctx).Info("failed to get secret associated with credentials request", "secret", name)
-		return false, nil
+		return nil, false, nil
 	} else if err != nil {
-		return false, err
+		return nil, false, err
 	}
 
-	return true, nil
+	return &secret, true, nil
+}
+
+// credentialsRequestSecretName returns the name of the secret provisioned by the CredentialsRequest of the controller.
+func credentialsRequestSecretName(controller *albo.AWSLoadBalancerController) string {
+	return fmt.Sprintf("%s-credentialsrequest-%s", controllerResourcePrefix, controller.Name)
+}
+
+// credentialsSecretName returns the name of the secret with the AWS credentials of the controller:
+// the user provided secret, the secret provisioned by the operator in WebIdentity mode
+// or the secret provisioned by the CredentialsRequest.
+func credentialsSecretName(controller *albo.AWSLoadBalancerController) string {
+	if controller.Spec.Credentials != nil {
+		return controller.Spec.Credentials.Name
+	}
+	if webIdentityCredentialsEnabled(controller) {
+		return webIdentityCredentialsSecretName(controller)
+	}
+	return credentialsRequestSecretName(controller)
 }
 
 func (r *AWSLoadBalancerControllerReconciler) createCredentialsRequest(ctx context.Context, desired *cco.CredentialsRequest) error {
@@ -11,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
 
+	configv1 "github.com/openshift/api/config/v1"
 	cco "github.com/openshift/cloud-credential-operator/pkg/apis/cloudcredential/v1"
 
 	"github.com/google/go-cmp/cmp"
@@ -168,6 +169,46 @@ func testCompleteCredentialsRequest() *cco.CredentialsRequest {
 	}
 }
 
+func TestCredentialsSecretName(t *testing.T) {
+	for _, tc := range []struct {
+		name                     string
+		credentials              *configv1.SecretNameReference
+		credentialsRequestConfig *albo.AWSLoadBalancerCredentialsRequestConfig
+		expectedName             string
+	}{
+		{
+			name:         "credentials request secret",
+			expectedName: "aws-load-balancer-controller-credentialsrequest-cluster",
+		},
+		{
+			name:         "user provided secret",
+			credentials:  &configv1.SecretNameReference{Name: "user-credentials"},
+			expectedName: "user-credentials",
+		},
+		{
+			name: "web identity secret",
+			credentialsRequestConfig: &albo.AWSLoadBalancerCredentialsRequestConfig{
+				STSIAMRoleARN: "arn:aws:iam::123456789012:role/test-role",
+				Mode:          albo.WebIdentityCredentialsMode,
+			},
+			expectedName: "aws-load-balancer-controller-webidentity-cluster",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := &albo.AWSLoadBalancerController{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Spec: albo.AWSLoadBalancerControllerSpec{
+					Credentials:              tc.credentials,
+					CredentialsRequestConfig: tc.credentialsRequestConfig,
+				},
+			}
+			if name := credentialsSecretName(controller); name != tc.expectedName {
+				t.Errorf("unexpected credentials secret name, expected %q, got %q", tc.expectedName, name)
+			}
+		})
+	}
+}
+
 func testCredentialsRequestProviderSpecDiff() *cco.CredentialsRequest {
 	cr := testCompleteCredentialsRequest()
 	cr.Spec.ProviderSpec = testAWSProviderSpec()
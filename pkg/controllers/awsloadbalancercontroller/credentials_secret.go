@@ -0,0 +1,160 @@
+package awsloadbalancercontroller
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// credentialsSecretKey is the key of the credentials secret with the AWS shared credentials file.
+	credentialsSecretKey = "credentials"
+	// defaultCredentialsProfile is the profile of the shared credentials file used by the controller.
+	defaultCredentialsProfile = "default"
+
+	accessKeyIDKey          = "aws_access_key_id"
+	secretAccessKeyKey      = "aws_secret_access_key"
+	roleARNKey              = "role_arn"
+	webIdentityTokenFileKey = "web_identity_token_file"
+)
+
+// credentialsSecretError describes why the credentials secret cannot be used by the controller.
+// The reason is reported in the CredentialsSecretAvailable condition.
+type credentialsSecretError struct {
+	reason  string
+	message string
+}
+
+func (e *credentialsSecretError) Error() string {
+	return e.message
+}
+
+// validateCredentialsSecret checks that the given secret contains a shared credentials file
+// which the controller can load: the default profile has to either provide the static credentials
+// or assume a role with a web identity token.
+func validateCredentialsSecret(secret *corev1.Secret) error {
+	data, found := secret.Data[credentialsSecretKey]
+	if !found || len(bytes.TrimSpace(data)) == 0 {
+		return &credentialsSecretError{
+			reason:  "CredentialsKeyMissing",
+			message: fmt.Sprintf("CredentialsSecret %q does not have the %q key", secret.Name, credentialsSecretKey),
+		}
+	}
+
+	profiles, err := parseSharedCredentials(data)
+	if err != nil {
+		return &credentialsSecretError{
+			reason:  "InvalidCredentialsSyntax",
+			message: fmt.Sprintf("CredentialsSecret %q has invalid credentials: %v", secret.Name, err),
+		}
+	}
+
+	profile, found := profiles[defaultCredentialsProfile]
+	if !found {
+		return &credentialsSecretError{
+			reason:  "DefaultProfileMissing",
+			message: fmt.Sprintf("CredentialsSecret %q does not have the %q profile", secret.Name, defaultCredentialsProfile),
+		}
+	}
+
+	_, hasAccessKeyID := profile[accessKeyIDKey]
+	_, hasSecretAccessKey := profile[secretAccessKeyKey]
+	roleARN, hasRoleARN := profile[roleARNKey]
+	_, hasWebIdentityTokenFile := profile[webIdentityTokenFileKey]
+
+	switch {
+	case hasRoleARN && (hasAccessKeyID || hasSecretAccessKey):
+		return &credentialsSecretError{
+			reason:  "ConflictingCredentialsModes",
+			message: fmt.Sprintf("CredentialsSecret %q has both the static credentials and %q in the %q profile", secret.Name, roleARNKey, defaultCredentialsProfile),
+		}
+	case hasRoleARN:
+		if err := validateRoleARN(roleARN); err != nil {
+			return &credentialsSecretError{
+				reason:  "InvalidRoleARN",
+				message: fmt.Sprintf("CredentialsSecret %q has invalid %q: %v", secret.Name, roleARNKey, err),
+			}
+		}
+		if !hasWebIdentityTokenFile {
+			return &credentialsSecretError{
+				reason:  "WebIdentityTokenFileMissing",
+				message: fmt.Sprintf("CredentialsSecret %q has %q without %q in the %q profile", secret.Name, roleARNKey, webIdentityTokenFileKey, defaultCredentialsProfile),
+			}
+		}
+	case hasWebIdentityTokenFile:
+		return &credentialsSecretError{
+			reason:  "RoleARNMissing",
+			message: fmt.Sprintf("CredentialsSecret %q has %q without %q in the %q profile", secret.Name, webIdentityTokenFileKey, roleARNKey, defaultCredentialsProfile),
+		}
+	case !hasAccessKeyID || !hasSecretAccessKey:
+		return &credentialsSecretError{
+			reason:  "StaticCredentialsIncomplete",
+			message: fmt.Sprintf("CredentialsSecret %q must have both %q and %q in the %q profile", secret.Name, accessKeyIDKey, secretAccessKeyKey, defaultCredentialsProfile),
+		}
+	}
+	return nil
+}
+
+// validateRoleARN checks that the given value is the ARN of an IAM role.
+func validateRoleARN(value string) error {
+	roleARN, err := arn.Parse(value)
+	if err != nil {
+		return err
+	}
+	if roleARN.Service != "iam" || !strings.HasPrefix(roleARN.Resource, "role/") {
+		return fmt.Errorf("%q is not the ARN of an IAM role", value)
+	}
+	return nil
+}
+
+// parseSharedCredentials parses the given AWS shared credentials file into the key values of each profile.
+// Only the subset of the INI syntax used by the shared credentials files is accepted:
+// the comments, the profile sections and the key value pairs.
+func parseSharedCredentials(data []byte) (map[string]map[string]string, error) {
+	profiles := map[string]map[string]string{}
+	var profile map[string]string
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";"):
+			continue
+		case strings.HasPrefix(line, "["):
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: unterminated profile section", lineNumber)
+			}
+			name := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line[1:len(line)-1]), "profile "))
+			if name == "" {
+				return nil, fmt.Errorf("line %d: empty profile name", lineNumber)
+			}
+			if _, found := profiles[name]; found {
+				return nil, fmt.Errorf("line %d: duplicate profile %q", lineNumber, name)
+			}
+			profile = map[string]string{}
+			profiles[name] = profile
+		default:
+			key, value, found := strings.Cut(line, "=")
+			if !found {
+				return nil, fmt.Errorf("line %d: expected a key value pair", lineNumber)
+			}
+			if profile == nil {
+				return nil, fmt.Errorf("line %d: key value pair outside of a profile", lineNumber)
+			}
+			key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+			if key == "" || value == "" {
+				return nil, fmt.Errorf("line %d: empty key or value", lineNumber)
+			}
+			profile[key] = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
@@ -0,0 +1,122 @@
+package awsloadbalancercontroller
+
+import (
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateCredentialsSecret(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		data           map[string][]byte
+		expectedReason string
+	}{
+		{
+			name: "static credentials",
+			data: map[string][]byte{
+				"credentials": []byte("[default]\naws_access_key_id = AKIAEXAMPLE\naws_secret_access_key = secret\n"),
+			},
+		},
+		{
+			name: "web identity",
+			data: map[string][]byte{
+				"credentials": []byte("# provisioned for sts\n[default]\nsts_regional_endpoints = regional\nrole_arn = arn:aws:iam::123456789012:role/albc\nweb_identity_token_file = /var/run/secrets/openshift/serviceaccount/token\n"),
+			},
+		},
+		{
+			name: "additional profile",
+			data: map[string][]byte{
+				"credentials": []byte("[default]\naws_access_key_id = AKIAEXAMPLE\naws_secret_access_key = secret\n\n[profile other]\nregion = us-east-1\n"),
+			},
+		},
+		{
+			name:           "credentials key missing",
+			data:           map[string][]byte{"aws_access_key_id": []byte("AKIAEXAMPLE")},
+			expectedReason: "CredentialsKeyMissing",
+		},
+		{
+			name:           "empty credentials",
+			data:           map[string][]byte{"credentials": []byte("  \n")},
+			expectedReason: "CredentialsKeyMissing",
+		},
+		{
+			name:           "unterminated section",
+			data:           map[string][]byte{"credentials": []byte("[default\naws_access_key_id = AKIAEXAMPLE\n")},
+			expectedReason: "InvalidCredentialsSyntax",
+		},
+		{
+			name:           "key outside of profile",
+			data:           map[string][]byte{"credentials": []byte("aws_access_key_id = AKIAEXAMPLE\n[default]\n")},
+			expectedReason: "InvalidCredentialsSyntax",
+		},
+		{
+			name:           "line without value",
+			data:           map[string][]byte{"credentials": []byte("[default]\naws_access_key_id\n")},
+			expectedReason: "InvalidCredentialsSyntax",
+		},
+		{
+			name:           "duplicate profile",
+			data:           map[string][]byte{"credentials": []byte("[default]\nregion = us-east-1\n[default]\nregion = us-west-1\n")},
+			expectedReason: "InvalidCredentialsSyntax",
+		},
+		{
+			name:           "default profile missing",
+			data:           map[string][]byte{"credentials": []byte("[other]\naws_access_key_id = AKIAEXAMPLE\naws_secret_access_key = secret\n")},
+			expectedReason: "DefaultProfileMissing",
+		},
+		{
+			name:           "secret access key missing",
+			data:           map[string][]byte{"credentials": []byte("[default]\naws_access_key_id = AKIAEXAMPLE\n")},
+			expectedReason: "StaticCredentialsIncomplete",
+		},
+		{
+			name:           "web identity token file missing",
+			data:           map[string][]byte{"credentials": []byte("[default]\nrole_arn = arn:aws:iam::123456789012:role/albc\n")},
+			expectedReason: "WebIdentityTokenFileMissing",
+		},
+		{
+			name:           "role arn missing",
+			data:           map[string][]byte{"credentials": []byte("[default]\nweb_identity_token_file = /var/run/secrets/openshift/serviceaccount/token\n")},
+			expectedReason: "RoleARNMissing",
+		},
+		{
+			name:           "malformed role arn",
+			data:           map[string][]byte{"credentials": []byte("[default]\nrole_arn = albc\nweb_identity_token_file = /token\n")},
+			expectedReason: "InvalidRoleARN",
+		},
+		{
+			name:           "arn of a user",
+			data:           map[string][]byte{"credentials": []byte("[default]\nrole_arn = arn:aws:iam::123456789012:user/albc\nweb_identity_token_file = /token\n")},
+			expectedReason: "InvalidRoleARN",
+		},
+		{
+			name:           "static credentials and role",
+			data:           map[string][]byte{"credentials": []byte("[default]\naws_access_key_id = AKIAEXAMPLE\naws_secret_access_key = secret\nrole_arn = arn:aws:iam::123456789012:role/albc\nweb_identity_token_file = /token\n")},
+			expectedReason: "ConflictingCredentialsModes",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-credentials", Namespace: "test-namespace"},
+				Data:       tc.data,
+			}
+			err := validateCredentialsSecret(secret)
+			if tc.expectedReason == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var credsErr *credentialsSecretError
+			if !errors.As(err, &credsErr) {
+				t.Fatalf("expected credentials secret error with reason %q, got %v", tc.expectedReason, err)
+			}
+			if credsErr.reason != tc.expectedReason {
+				t.Errorf("unexpected reason, expected %q, got %q: %v", tc.expectedReason, credsErr.reason, err)
+			}
+		})
+	}
+}
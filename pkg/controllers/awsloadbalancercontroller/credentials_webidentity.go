@@ -0,0 +1,112 @@
+package awsloadbalancercontroller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	albo "github.com/openshift/aws-load-balancer-operator/api/v1"
+)
+
+const (
+	// openshiftTokenAudience is the audience of the service account token expected by the Cloud Credential Operator.
+	openshiftTokenAudience = "openshift"
+	// defaultWebIdentityTokenAudience is the audience of the service account token in WebIdentity mode,
+	// it's the audience trusted by the IAM roles for service accounts.
+	defaultWebIdentityTokenAudience = "sts.amazonaws.com"
+	// defaultTokenExpirationSeconds is the validity of the service account token.
+	defaultTokenExpirationSeconds = int64(3600)
+)
+
+// webIdentityCredentialsEnabled returns true if the operator provisions the credentials secret
+// which assumes the IAM role with the service account token of the controller.
+func webIdentityCredentialsEnabled(controller *albo.AWSLoadBalancerController) bool {
+	config := controller.Spec.CredentialsRequestConfig
+	return controller.Spec.Credentials == nil && config != nil && config.Mode == albo.WebIdentityCredentialsMode
+}
+
+// webIdentityCredentialsSecretName returns the name of the credentials secret provisioned by the operator in WebIdentity mode.
+func webIdentityCredentialsSecretName(controller *albo.AWSLoadBalancerController) string {
+	return fmt.Sprintf("%s-webidentity-%s", controllerResourcePrefix, controller.Name)
+}
+
+// serviceAccountTokenProjection returns the audience and the expiration of the service account token
+// projected into the controller pod.
+func serviceAccountTokenProjection(controller *albo.AWSLoadBalancerController) (string, int64) {
+	if !webIdentityCredentialsEnabled(controller) {
+		return openshiftTokenAudience, defaultTokenExpirationSeconds
+	}
+	config := controller.Spec.CredentialsRequestConfig
+	audience, expiration := defaultWebIdentityTokenAudience, defaultTokenExpirationSeconds
+	if config.TokenAudience != "" {
+		audience = config.TokenAudience
+	}
+	if config.TokenExpirationSeconds != 0 {
+		expiration = config.TokenExpirationSeconds
+	}
+	return audience, expiration
+}
+
+// ensureWebIdentityCredentialsSecret ensures the credentials secret which assumes the IAM role from the CredentialsRequest config
+// with the service account token projected into the controller pod. No CredentialsRequest is needed.
+func (r *AWSLoadBalancerControllerReconciler) ensureWebIdentityCredentialsSecret(ctx context.Context, namespace string, controller *albo.AWSLoadBalancerController) (*corev1.Secret, error) {
+	nsName := types.NamespacedName{Namespace: namespace, Name: webIdentityCredentialsSecretName(controller)}
+
+	reqLogger := log.FromContext(ctx).WithValues("secret", nsName)
+	reqLogger.Info("ensuring web identity credentials secret for aws-load-balancer-controller instance")
+
+	desired := desiredWebIdentityCredentialsSecret(nsName, controller.Spec.CredentialsRequestConfig.STSIAMRoleARN)
+	if err := controllerutil.SetControllerReference(controller, desired, r.Scheme); err != nil {
+		return nil, fmt.Errorf("failed to set the controller reference for secret %q: %w", nsName.Name, err)
+	}
+
+	current := &corev1.Secret{}
+	if err := r.Client.Get(ctx, nsName, current); err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get existing secret %q: %w", nsName.Name, err)
+		}
+		if err := r.Client.Create(ctx, desired); err != nil {
+			return nil, fmt.Errorf("failed to create secret %q: %w", nsName.Name, err)
+		}
+		reqLogger.Info("successfully created web identity credentials secret")
+		return desired, nil
+	}
+
+	if bytes.Equal(current.Data[credentialsSecretKey], desired.Data[credentialsSecretKey]) {
+		return current, nil
+	}
+	updated := current.DeepCopy()
+	updated.Data = desired.Data
+	if err := r.Client.Update(ctx, updated); err != nil {
+		return nil, fmt.Errorf("failed to update secret %q: %w", nsName.Name, err)
+	}
+	reqLogger.Info("successfully updated web identity credentials secret")
+	return updated, nil
+}
+
+// desiredWebIdentityCredentialsSecret returns the secret with the shared credentials file which assumes the given role
+// with the projected service account token, in the same format as the secret provisioned by the Cloud Credential Operator on STS clusters.
+func desiredWebIdentityCredentialsSecret(nsName types.NamespacedName, roleARN string) *corev1.Secret {
+	credentials := fmt.Sprintf("[%s]\nsts_regional_endpoints = regional\n%s = %s\n%s = %s\n",
+		defaultCredentialsProfile,
+		roleARNKey, roleARN,
+		webIdentityTokenFileKey, path.Join(boundSATokenDir, "token"))
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nsName.Name,
+			Namespace: nsName.Namespace,
+		},
+		Data: map[string][]byte{
+			credentialsSecretKey: []byte(credentials),
+		},
+	}
+}
@@ -0,0 +1,146 @@
+package awsloadbalancercontroller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	albo "github.com/openshift/aws-load-balancer-operator/api/v1"
+	"github.com/openshift/aws-load-balancer-operator/pkg/utils/test"
+)
+
+func TestEnsureWebIdentityCredentialsSecret(t *testing.T) {
+	expectedCredentials := "[default]\nsts_regional_endpoints = regional\nrole_arn = arn:aws:iam::123456789012:role/test-role\nweb_identity_token_file = /var/run/secrets/openshift/serviceaccount/token\n"
+
+	for _, tc := range []struct {
+		name            string
+		existingObjects []client.Object
+	}{
+		{
+			name: "secret created",
+		},
+		{
+			name: "secret with another role updated",
+			existingObjects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "aws-load-balancer-controller-webidentity-cluster", Namespace: test.OperatorNamespace},
+					Data: map[string][]byte{
+						"credentials": []byte("[default]\nrole_arn = arn:aws:iam::123456789012:role/other-role\nweb_identity_token_file = /var/run/secrets/openshift/serviceaccount/token\n"),
+					},
+				},
+			},
+		},
+		{
+			name: "secret up to date",
+			existingObjects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "aws-load-balancer-controller-webidentity-cluster", Namespace: test.OperatorNamespace},
+					Data: map[string][]byte{
+						"credentials": []byte(expectedCredentials),
+					},
+				},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := &albo.AWSLoadBalancerController{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Spec: albo.AWSLoadBalancerControllerSpec{
+					CredentialsRequestConfig: &albo.AWSLoadBalancerCredentialsRequestConfig{
+						STSIAMRoleARN: "arn:aws:iam::123456789012:role/test-role",
+						Mode:          albo.WebIdentityCredentialsMode,
+					},
+				},
+			}
+			client := fake.NewClientBuilder().WithScheme(test.Scheme).WithObjects(tc.existingObjects...).Build()
+			r := &AWSLoadBalancerControllerReconciler{
+				Client: client,
+				Scheme: test.Scheme,
+			}
+
+			secret, err := r.ensureWebIdentityCredentialsSecret(context.Background(), test.OperatorNamespace, controller)
+			if err != nil {
+				t.Fatalf("got unexpected error: %v", err)
+			}
+			if secret.Name != "aws-load-balancer-controller-webidentity-cluster" {
+				t.Errorf("unexpected secret name %q", secret.Name)
+			}
+
+			var current corev1.Secret
+			if err := client.Get(context.Background(), types.NamespacedName{Namespace: test.OperatorNamespace, Name: secret.Name}, &current); err != nil {
+				t.Fatalf("failed to get the secret: %v", err)
+			}
+			if got := string(current.Data["credentials"]); got != expectedCredentials {
+				t.Errorf("unexpected credentials, expected %q, got %q", expectedCredentials, got)
+			}
+			if err := validateCredentialsSecret(&current); err != nil {
+				t.Errorf("expected a valid credentials secret, got %v", err)
+			}
+		})
+	}
+}
+
+func TestServiceAccountTokenProjection(t *testing.T) {
+	for _, tc := range []struct {
+		name               string
+		config             *albo.AWSLoadBalancerCredentialsRequestConfig
+		expectedAudience   string
+		expectedExpiration int64
+	}{
+		{
+			name:               "no credentials request config",
+			expectedAudience:   "openshift",
+			expectedExpiration: 3600,
+		},
+		{
+			name: "credentials request mode ignores the token settings",
+			config: &albo.AWSLoadBalancerCredentialsRequestConfig{
+				STSIAMRoleARN:          "arn:aws:iam::123456789012:role/test-role",
+				Mode:                   albo.CredentialsRequestCredentialsMode,
+				TokenAudience:          "custom",
+				TokenExpirationSeconds: 7200,
+			},
+			expectedAudience:   "openshift",
+			expectedExpiration: 3600,
+		},
+		{
+			name: "web identity mode defaults",
+			config: &albo.AWSLoadBalancerCredentialsRequestConfig{
+				STSIAMRoleARN: "arn:aws:iam::123456789012:role/test-role",
+				Mode:          albo.WebIdentityCredentialsMode,
+			},
+			expectedAudience:   "sts.amazonaws.com",
+			expectedExpiration: 3600,
+		},
+		{
+			name: "web identity mode with custom token",
+			config: &albo.AWSLoadBalancerCredentialsRequestConfig{
+				STSIAMRoleARN:          "arn:aws:iam::123456789012:role/test-role",
+				Mode:                   albo.WebIdentityCredentialsMode,
+				TokenAudience:          "custom",
+				TokenExpirationSeconds: 7200,
+			},
+			expectedAudience:   "custom",
+			expectedExpiration: 7200,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := &albo.AWSLoadBalancerController{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Spec:       albo.AWSLoadBalancerControllerSpec{CredentialsRequestConfig: tc.config},
+			}
+			audience, expiration := serviceAccountTokenProjection(controller)
+			if audience != tc.expectedAudience {
+				t.Errorf("unexpected audience, expected %q, got %q", tc.expectedAudience, audience)
+			}
+			if expiration != tc.expectedExpiration {
+				t.Errorf("unexpected expiration, expected %d, got %d", tc.expectedExpiration, expiration)
+			}
+		})
+	}
+}
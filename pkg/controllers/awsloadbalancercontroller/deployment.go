@@ -10,6 +10,7 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -36,6 +37,10 @@ const (
 	// The rollout is necessary because 1) the trusted configmap is consumed as a subPath which forbids the updates,
 	// 2) the controller doesn't have a means (fsnotify or similar) to detect the updates anyway.
 	trustedCAAnnotation = "networking.olm.openshift.io/trusted-ca-configmap-hash"
+	// credentialsSecretAnnotation is the annotation which contains the hash of the credentials secret's contents.
+	// Like the trusted CA annotation, it's added to the template pod spec of the controller deployment
+	// to roll out the controller when the credentials are rotated as the controller loads them only at startup.
+	credentialsSecretAnnotation = "networking.olm.openshift.io/credentials-secret-hash"
 	// awsLoadBalancerControllerContainerName is the name of the AWS load balancer controller's container.
 	awsLoadBalancerControllerContainerName = "controller"
 	// awsSDKLoadConfigName is the name of the environment variable which enables shared configs.
@@ -71,7 +76,9 @@ const (
 	allCapabilities = "ALL"
 )
 
-func (r *AWSLoadBalancerControllerReconciler) ensureDeployment(ctx context.Context, sa *corev1.ServiceAccount, crSecretName, servingSecretName string, controller *albo.AWSLoadBalancerController, platformStatus *configv1.PlatformStatus, trustCAConfigMap *corev1.ConfigMap) (*appsv1.Deployment, error) {
+// ensureDeployment ensures the deployment of the controller.
+// Returns true if the deployment is rolled out because the content of the credentials secret changed.
+func (r *AWSLoadBalancerControllerReconciler) ensureDeployment(ctx context.Context, sa *corev1.ServiceAccount, credentialsSecret *corev1.Secret, servingSecretName string, controller *albo.AWSLoadBalancerController, platformStatus *configv1.PlatformStatus, trustCAConfigMap *corev1.ConfigMap) (*appsv1.Deployment, bool, error) {
 	deploymentName := fmt.Sprintf("%s-%s", controllerResourcePrefix, controller.Name)
 
 	reqLogger := log.FromContext(ctx).WithValues("deployment", deploymentName)
@@ -79,7 +86,7 @@ func (r *AWSLoadBalancerControllerReconciler) ensureDeployment(ctx context.Conte
 
 	exists, current, err := r.currentDeployment(ctx, deploymentName, r.Namespace)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get existing deployment %s: %w", deploymentName, err)
+		return nil, false, fmt.Errorf("failed to get existing deployment %s: %w", deploymentName, err)
 	}
 
 	trustCAConfigMapName, trustCAConfigMapHash := "", ""
@@ -87,43 +94,53 @@ func (r *AWSLoadBalancerControllerReconciler) ensureDeployment(ctx context.Conte
 		trustCAConfigMapName = trustCAConfigMap.Name
 		configMapHash, err := buildMapHash(trustCAConfigMap.Data)
 		if err != nil {
-			return nil, fmt.Errorf("failed to build the trusted CA configmap's hash: %w", err)
+			return nil, false, fmt.Errorf("failed to build the trusted CA configmap's hash: %w", err)
 		}
 		trustCAConfigMapHash = configMapHash
 	}
 
-	desired := r.desiredDeployment(deploymentName, crSecretName, servingSecretName, controller, platformStatus, sa, trustCAConfigMapName, trustCAConfigMapHash)
+	credentialsSecretHash, err := buildSecretHash(credentialsSecret.Data)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to build the credentials secret's hash: %w", err)
+	}
+
+	desired := r.desiredDeployment(deploymentName, credentialsSecret.Name, credentialsSecretHash, servingSecretName, controller, platformStatus, sa, trustCAConfigMapName, trustCAConfigMapHash)
 
 	err = controllerutil.SetControllerReference(controller, desired, r.Scheme)
 	if err != nil {
-		return nil, fmt.Errorf("failed to set owner reference on deployment %s: %w", deploymentName, err)
+		return nil, false, fmt.Errorf("failed to set owner reference on deployment %s: %w", deploymentName, err)
 	}
 
 	if !exists {
 		err = r.createDeployment(ctx, desired)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create deployment %s: %w", deploymentName, err)
+			return nil, false, fmt.Errorf("failed to create deployment %s: %w", deploymentName, err)
 		}
 		_, current, err = r.currentDeployment(ctx, deploymentName, r.Namespace)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get new deployment %s: %w", deploymentName, err)
+			return nil, false, fmt.Errorf("failed to get new deployment %s: %w", deploymentName, err)
 		}
-		return current, nil
+		return current, false, nil
 	}
+	// the deployments created before the credentials hash was introduced are not considered rotated
+	currentCredentialsHash := current.Spec.Template.Annotations[credentialsSecretAnnotation]
+	rotated := currentCredentialsHash != "" && currentCredentialsHash != credentialsSecretHash
 	updated, err := r.updateDeployment(ctx, current, desired)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update existing deployment: %w", err)
+		return nil, false, fmt.Errorf("failed to update existing deployment: %w", err)
 	}
 	if updated {
 		_, current, err = r.currentDeployment(ctx, deploymentName, r.Namespace)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get existing deployment: %w", err)
+			return nil, false, fmt.Errorf("failed to get existing deployment: %w", err)
 		}
 	}
-	return current, nil
+	return current, rotated, nil
 }
 
-func (r *AWSLoadBalancerControllerReconciler) desiredDeployment(name, credentialsRequestSecretName, servingSecret string, controller *albo.AWSLoadBalancerController, platformStatus *configv1.PlatformStatus, sa *corev1.ServiceAccount, trustedCAConfigMapName, trustedCAConfigMapHash string) *appsv1.Deployment {
+func (r *AWSLoadBalancerControllerReconciler) desiredDeployment(name, credentialsRequestSecretName, credentialsSecretHash, servingSecret string, controller *albo.AWSLoadBalancerController, platformStatus *configv1.PlatformStatus, sa *corev1.ServiceAccount, trustedCAConfigMapName, trustedCAConfigMapHash string) *appsv1.Deployment {
+	featureGates, _ := r.desiredFeatureGates(controller)
+	tokenAudience, tokenExpirationSeconds := serviceAccountTokenProjection(controller)
 	d := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
@@ -148,7 +165,7 @@ func (r *AWSLoadBalancerControllerReconciler) desiredDeployment(name, credential
 						{
 							Name:  awsLoadBalancerControllerContainerName,
 							Image: r.Image,
-							Args:  desiredContainerArgs(controller, r.ClusterName, r.VPCID, platformStatus),
+							Args:  desiredContainerArgs(controller, r.ClusterName, r.VPCID, platformStatus, featureGates),
 							Env: append([]corev1.EnvVar{
 								{
 									Name:  awsRegionEnvVarName,
@@ -220,8 +237,8 @@ func (r *AWSLoadBalancerControllerReconciler) desiredDeployment(name, credential
 									DefaultMode: ptr.To[int32](420),
 									Sources: []corev1.VolumeProjection{{
 										ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
-											Audience:          "openshift",
-											ExpirationSeconds: ptr.To[int64](3600),
+											Audience:          tokenAudience,
+											ExpirationSeconds: ptr.To[int64](tokenExpirationSeconds),
 											Path:              "token",
 										},
 									}},
@@ -233,8 +250,25 @@ func (r *AWSLoadBalancerControllerReconciler) desiredDeployment(name, credential
 			},
 		},
 	}
-	if controller.Spec.Config != nil && controller.Spec.Config.Replicas != 0 {
-		d.Spec.Replicas = ptr.To[int32](controller.Spec.Config.Replicas)
+	if config := controller.Spec.Config; config != nil {
+		if config.Replicas != 0 {
+			d.Spec.Replicas = ptr.To[int32](config.Replicas)
+		}
+		if config.Resources != nil {
+			for i := range d.Spec.Template.Spec.Containers {
+				d.Spec.Template.Spec.Containers[i].Resources = *config.Resources.DeepCopy()
+			}
+		}
+		d.Spec.Template.Spec.NodeSelector = config.NodeSelector
+		d.Spec.Template.Spec.Tolerations = config.Tolerations
+		d.Spec.Template.Spec.Affinity = config.Affinity
+	}
+	d.Spec.Template.Spec.TopologySpreadConstraints = desiredTopologySpreadConstraints(controllerReplicas(controller), d.Spec.Selector.MatchLabels)
+	if credentialsSecretHash != "" {
+		if d.Spec.Template.Annotations == nil {
+			d.Spec.Template.Annotations = map[string]string{}
+		}
+		d.Spec.Template.Annotations[credentialsSecretAnnotation] = credentialsSecretHash
 	}
 	if trustedCAConfigMapName != "" {
 		if trustedCAConfigMapHash != "" {
@@ -263,7 +297,7 @@ func (r *AWSLoadBalancerControllerReconciler) desiredDeployment(name, credential
 	return d
 }
 
-func desiredContainerArgs(controller *albo.AWSLoadBalancerController, clusterName, vpcID string, platformStatus *configv1.PlatformStatus) []string {
+func desiredContainerArgs(controller *albo.AWSLoadBalancerController, clusterName, vpcID string, platformStatus *configv1.PlatformStatus, featureGates []string) []string {
 	var args []string
 	args = append(args, fmt.Sprintf("--webhook-cert-dir=%s", webhookTLSDir))
 	args = append(args, fmt.Sprintf("--aws-vpc-id=%s", vpcID))
@@ -274,6 +308,7 @@ func desiredContainerArgs(controller *albo.AWSLoadBalancerController, clusterNam
 		sort.Strings(tags)
 		args = append(args, fmt.Sprintf(`--default-tags=%s`, strings.Join(tags, ",")))
 	}
+	// the Ingresses are assigned to the controller through the IngressClasses only, including the default IngressClass.
 	args = append(args, "--disable-ingress-class-annotation")
 	args = append(args, "--disable-ingress-group-name-annotation")
 	if controller.Spec.Config != nil && controller.Spec.Config.Replicas > 1 {
@@ -299,11 +334,63 @@ func desiredContainerArgs(controller *albo.AWSLoadBalancerController, clusterNam
 		args = append(args, "--enable-wafv2=false")
 	}
 	args = append(args, fmt.Sprintf("--ingress-class=%s", controller.Spec.IngressClass))
-	args = append(args, "--feature-gates=EnableIPTargetType=false")
+	args = append(args, fmt.Sprintf("--feature-gates=%s", strings.Join(featureGates, ",")))
+	args = append(args, controllerConfigArgs(controller.Spec.ControllerConfig)...)
 	sort.Strings(args)
 	return args
 }
 
+// controllerConfigArgs returns the controller flags of the set fields of the given config.
+func controllerConfigArgs(config *albo.AWSLoadBalancerControllerConfig) []string {
+	if config == nil {
+		return nil
+	}
+	var args []string
+	if config.LogLevel != "" {
+		args = append(args, fmt.Sprintf("--log-level=%s", strings.ToLower(string(config.LogLevel))))
+	}
+	if config.DefaultSSLPolicy != "" {
+		args = append(args, fmt.Sprintf("--default-ssl-policy=%s", config.DefaultSSLPolicy))
+	}
+	if config.DefaultTargetType != "" {
+		args = append(args, fmt.Sprintf("--default-target-type=%s", strings.ToLower(string(config.DefaultTargetType))))
+	}
+	if config.SyncPeriod != nil {
+		args = append(args, fmt.Sprintf("--sync-period=%s", config.SyncPeriod.Duration))
+	}
+	if config.IngressMaxConcurrentReconciles != nil {
+		args = append(args, fmt.Sprintf("--ingress-max-concurrent-reconciles=%d", *config.IngressMaxConcurrentReconciles))
+	}
+	if config.TargetGroupBindingMaxConcurrentReconciles != nil {
+		args = append(args, fmt.Sprintf("--targetgroupbinding-max-concurrent-reconciles=%d", *config.TargetGroupBindingMaxConcurrentReconciles))
+	}
+	if config.AWSMaxRetries != nil {
+		args = append(args, fmt.Sprintf("--aws-max-retries=%d", *config.AWSMaxRetries))
+	}
+	if len(config.AWSAPIThrottle) > 0 {
+		throttles := make([]string, 0, len(config.AWSAPIThrottle))
+		for _, t := range config.AWSAPIThrottle {
+			throttles = append(throttles, string(t))
+		}
+		args = append(args, fmt.Sprintf("--aws-api-throttle=%s", strings.Join(throttles, ",")))
+	}
+	if config.EnableBackendSecurityGroup != nil {
+		args = append(args, fmt.Sprintf("--enable-backend-security-group=%t", *config.EnableBackendSecurityGroup))
+	}
+	if config.BackendSecurityGroup != "" {
+		args = append(args, fmt.Sprintf("--backend-security-group=%s", config.BackendSecurityGroup))
+	}
+	if config.DisableRestrictedSecurityGroupRules != nil {
+		args = append(args, fmt.Sprintf("--disable-restricted-sg-rules=%t", *config.DisableRestrictedSecurityGroupRules))
+	}
+	return args
+}
+
+// ipTargetTypeEnabled returns true if the controller is allowed to register the pods as targets.
+func ipTargetTypeEnabled(controller *albo.AWSLoadBalancerController) bool {
+	return controller.Spec.TargetType == albo.IPTargetType
+}
+
 func (r *AWSLoadBalancerControllerReconciler) currentDeployment(ctx context.Context, name string, namespace string) (bool, *appsv1.Deployment, error) {
 	var deployment appsv1.Deployment
 	err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &deployment)
@@ -381,6 +468,24 @@ func (r *AWSLoadBalancerControllerReconciler) updateDeployment(ctx context.Conte
 		outdated = true
 	}
 
+	// the scheduling constraints are fully owned by the operator, they are reset if changed
+	if !equality.Semantic.DeepEqual(updated.Spec.Template.Spec.NodeSelector, desired.Spec.Template.Spec.NodeSelector) {
+		updated.Spec.Template.Spec.NodeSelector = desired.Spec.Template.Spec.NodeSelector
+		outdated = true
+	}
+	if !equality.Semantic.DeepEqual(updated.Spec.Template.Spec.Tolerations, desired.Spec.Template.Spec.Tolerations) {
+		updated.Spec.Template.Spec.Tolerations = desired.Spec.Template.Spec.Tolerations
+		outdated = true
+	}
+	if !equality.Semantic.DeepEqual(updated.Spec.Template.Spec.Affinity, desired.Spec.Template.Spec.Affinity) {
+		updated.Spec.Template.Spec.Affinity = desired.Spec.Template.Spec.Affinity
+		outdated = true
+	}
+	if !equality.Semantic.DeepEqual(updated.Spec.Template.Spec.TopologySpreadConstraints, desired.Spec.Template.Spec.TopologySpreadConstraints) {
+		updated.Spec.Template.Spec.TopologySpreadConstraints = desired.Spec.Template.Spec.TopologySpreadConstraints
+		outdated = true
+	}
+
 	if outdated {
 		err := r.Update(ctx, updated)
 		if err != nil {
@@ -410,6 +515,39 @@ func haveVolumesChanged(current []corev1.Volume, desired []corev1.Volume) bool {
 				return true
 			}
 		}
+		if dv.Projected != nil {
+			if cv.Projected == nil {
+				return true
+			}
+			if haveServiceAccountTokensChanged(cv.Projected.Sources, dv.Projected.Sources) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// haveServiceAccountTokensChanged compares the service account token projections of the given volume sources:
+// the audience, expiration and path of the bound token are the settings the operator manages.
+func haveServiceAccountTokensChanged(current, desired []corev1.VolumeProjection) bool {
+	if len(current) != len(desired) {
+		return true
+	}
+	for i := range desired {
+		dt := desired[i].ServiceAccountToken
+		if dt == nil {
+			continue
+		}
+		ct := current[i].ServiceAccountToken
+		if ct == nil {
+			return true
+		}
+		if ct.Audience != dt.Audience || ct.Path != dt.Path {
+			return true
+		}
+		if dt.ExpirationSeconds != nil && ptr.Deref(ct.ExpirationSeconds, 0) != *dt.ExpirationSeconds {
+			return true
+		}
 	}
 	return false
 }
@@ -426,6 +564,11 @@ func hasContainerChanged(current, desired corev1.Container) bool {
 		return true
 	}
 
+	// quantities are compared by value: 1000m and 1 are the same CPU amount
+	if !equality.Semantic.DeepEqual(current.Resources, defaultedResources(desired.Resources)) {
+		return true
+	}
+
 	if len(current.Env) != len(desired.Env) {
 		return true
 	}
@@ -453,6 +596,22 @@ func hasContainerChanged(current, desired corev1.Container) bool {
 	return false
 }
 
+// defaultedResources returns the given resources with the defaulting of the API server applied:
+// the requests which are not set default to the limits.
+func defaultedResources(resources corev1.ResourceRequirements) corev1.ResourceRequirements {
+	defaulted := *resources.DeepCopy()
+	for name, limit := range defaulted.Limits {
+		if _, ok := defaulted.Requests[name]; ok {
+			continue
+		}
+		if defaulted.Requests == nil {
+			defaulted.Requests = corev1.ResourceList{}
+		}
+		defaulted.Requests[name] = limit.DeepCopy()
+	}
+	return defaulted
+}
+
 func hasSecurityContextChanged(current, desired *corev1.SecurityContext) bool {
 	if desired == nil {
 		return false
@@ -521,6 +680,15 @@ func equalBoolPtr(current, desired *bool) bool {
 	return true
 }
 
+// buildSecretHash is a utility function to get a checksum of the data of a secret.
+func buildSecretHash(data map[string][]byte) (string, error) {
+	stringData := make(map[string]string, len(data))
+	for k, v := range data {
+		stringData[k] = string(v)
+	}
+	return buildMapHash(stringData)
+}
+
 // buildMapHash is a utility function to get a checksum of a data map.
 func buildMapHash(data map[string]string) (string, error) {
 	keys := make([]string, 0, len(data))
@@ -6,13 +6,16 @@ import (
 	"os"
 	"sort"
 	"testing"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/utils/ptr"
 
 	"github.com/google/go-cmp/cmp"
@@ -33,6 +36,7 @@ func TestDesiredArgs(t *testing.T) {
 		name           string
 		controller     *albo.AWSLoadBalancerController
 		platformStatus *configv1.PlatformStatus
+		operandVersion *version.Version
 		expectedArgs   sets.Set[string]
 	}{
 		{
@@ -47,6 +51,138 @@ func TestDesiredArgs(t *testing.T) {
 				"--enable-waf=false",
 				"--enable-wafv2=false",
 				"--ingress-class=special-ingress-class",
+				"--feature-gates=EnableIPTargetType=false",
+			),
+		},
+		{
+			name: "ip target type",
+			controller: &albo.AWSLoadBalancerController{
+				Spec: albo.AWSLoadBalancerControllerSpec{
+					TargetType: albo.IPTargetType,
+				},
+			},
+			expectedArgs: sets.New[string](
+				"--enable-shield=false",
+				"--enable-waf=false",
+				"--enable-wafv2=false",
+				"--ingress-class=alb",
+				"--feature-gates=EnableIPTargetType=true",
+			),
+		},
+		{
+			name: "feature gates",
+			controller: &albo.AWSLoadBalancerController{
+				Spec: albo.AWSLoadBalancerControllerSpec{
+					TargetType: albo.IPTargetType,
+					FeatureGates: map[string]bool{
+						"SubnetsClusterTagCheck": false,
+						"NLBSecurityGroup":       true,
+					},
+				},
+			},
+			operandVersion: version.MustParseSemantic("v2.8.2"),
+			expectedArgs: sets.New[string](
+				"--enable-shield=false",
+				"--enable-waf=false",
+				"--enable-wafv2=false",
+				"--ingress-class=alb",
+				"--feature-gates=EnableIPTargetType=true,NLBSecurityGroup=true,SubnetsClusterTagCheck=false",
+			),
+		},
+		{
+			name: "unsupported feature gates",
+			controller: &albo.AWSLoadBalancerController{
+				Spec: albo.AWSLoadBalancerControllerSpec{
+					FeatureGates: map[string]bool{
+						"EnableRGTAPI":       true,
+						"NLBSecurityGroup":   false,
+						"UnknownFeatureGate": true,
+						"EnableIPTargetType": true,
+					},
+				},
+			},
+			operandVersion: version.MustParseSemantic("v2.5.4"),
+			expectedArgs: sets.New[string](
+				"--enable-shield=false",
+				"--enable-waf=false",
+				"--enable-wafv2=false",
+				"--ingress-class=alb",
+				"--feature-gates=EnableIPTargetType=false,EnableRGTAPI=true",
+			),
+		},
+		{
+			name: "feature gates of unknown operand version",
+			controller: &albo.AWSLoadBalancerController{
+				Spec: albo.AWSLoadBalancerControllerSpec{
+					FeatureGates: map[string]bool{
+						"ListenerRulesTagging": true,
+						"NLBSecurityGroup":     true,
+					},
+				},
+			},
+			expectedArgs: sets.New[string](
+				"--enable-shield=false",
+				"--enable-waf=false",
+				"--enable-wafv2=false",
+				"--ingress-class=alb",
+				"--feature-gates=EnableIPTargetType=false,ListenerRulesTagging=true",
+			),
+		},
+		{
+			name: "controller config",
+			controller: &albo.AWSLoadBalancerController{
+				Spec: albo.AWSLoadBalancerControllerSpec{
+					TargetType: albo.IPTargetType,
+					ControllerConfig: &albo.AWSLoadBalancerControllerConfig{
+						LogLevel:                       albo.DebugControllerLogLevel,
+						DefaultSSLPolicy:               "ELBSecurityPolicy-TLS13-1-2-2021-06",
+						DefaultTargetType:              albo.IPTargetType,
+						SyncPeriod:                     &metav1.Duration{Duration: 90 * time.Minute},
+						IngressMaxConcurrentReconciles: ptr.To[int32](5),
+						TargetGroupBindingMaxConcurrentReconciles: ptr.To[int32](10),
+						AWSMaxRetries: ptr.To[int32](3),
+						AWSAPIThrottle: []albo.AWSAPIThrottle{
+							"Elastic Load Balancing v2:Describe.*=10:20",
+							"EC2:DescribeSubnets=5:10",
+						},
+						EnableBackendSecurityGroup:          ptr.To(true),
+						BackendSecurityGroup:                "sg-0123456789abcdef0",
+						DisableRestrictedSecurityGroupRules: ptr.To(false),
+					},
+				},
+			},
+			expectedArgs: sets.New[string](
+				"--enable-shield=false",
+				"--enable-waf=false",
+				"--enable-wafv2=false",
+				"--ingress-class=alb",
+				"--feature-gates=EnableIPTargetType=true",
+				"--log-level=debug",
+				"--default-ssl-policy=ELBSecurityPolicy-TLS13-1-2-2021-06",
+				"--default-target-type=ip",
+				"--sync-period=1h30m0s",
+				"--ingress-max-concurrent-reconciles=5",
+				"--targetgroupbinding-max-concurrent-reconciles=10",
+				"--aws-max-retries=3",
+				"--aws-api-throttle=Elastic Load Balancing v2:Describe.*=10:20,EC2:DescribeSubnets=5:10",
+				"--enable-backend-security-group=true",
+				"--backend-security-group=sg-0123456789abcdef0",
+				"--disable-restricted-sg-rules=false",
+			),
+		},
+		{
+			name: "empty controller config",
+			controller: &albo.AWSLoadBalancerController{
+				Spec: albo.AWSLoadBalancerControllerSpec{
+					ControllerConfig: &albo.AWSLoadBalancerControllerConfig{},
+				},
+			},
+			expectedArgs: sets.New[string](
+				"--enable-shield=false",
+				"--enable-waf=false",
+				"--enable-wafv2=false",
+				"--ingress-class=alb",
+				"--feature-gates=EnableIPTargetType=false",
 			),
 		},
 		{
@@ -61,6 +197,7 @@ func TestDesiredArgs(t *testing.T) {
 				"--enable-waf=false",
 				"--enable-wafv2=false",
 				"--ingress-class=alb",
+				"--feature-gates=EnableIPTargetType=false",
 				"--enable-leader-election",
 			),
 		},
@@ -78,6 +215,7 @@ func TestDesiredArgs(t *testing.T) {
 				"--enable-waf=true",
 				"--enable-wafv2=false",
 				"--ingress-class=alb",
+				"--feature-gates=EnableIPTargetType=false",
 			),
 		},
 		{
@@ -94,6 +232,7 @@ func TestDesiredArgs(t *testing.T) {
 				"--enable-waf=false",
 				"--enable-wafv2=true",
 				"--ingress-class=alb",
+				"--feature-gates=EnableIPTargetType=false",
 			),
 		},
 		{
@@ -110,6 +249,7 @@ func TestDesiredArgs(t *testing.T) {
 				"--enable-waf=false",
 				"--enable-wafv2=false",
 				"--ingress-class=alb",
+				"--feature-gates=EnableIPTargetType=false",
 			),
 		},
 		{
@@ -128,6 +268,7 @@ func TestDesiredArgs(t *testing.T) {
 				"--enable-waf=false",
 				"--enable-wafv2=false",
 				"--ingress-class=alb",
+				"--feature-gates=EnableIPTargetType=false",
 				"--default-tags=test-key1=test-value1,test-key2=test-value2,test-key3=test-value3",
 			),
 		},
@@ -150,6 +291,7 @@ func TestDesiredArgs(t *testing.T) {
 				"--enable-waf=false",
 				"--enable-wafv2=false",
 				"--ingress-class=alb",
+				"--feature-gates=EnableIPTargetType=false",
 				"--default-tags=key1=value1,key2=value2",
 			),
 		},
@@ -179,6 +321,7 @@ func TestDesiredArgs(t *testing.T) {
 				"--enable-waf=false",
 				"--enable-wafv2=false",
 				"--ingress-class=alb",
+				"--feature-gates=EnableIPTargetType=false",
 				"--default-tags=conflict-key1=op-value2,conflict-key2=op-value3,op-key1=op-value1,plat-key1=plat-value1",
 			),
 		},
@@ -206,6 +349,7 @@ func TestDesiredArgs(t *testing.T) {
 				"--enable-waf=false",
 				"--enable-wafv2=false",
 				"--ingress-class=alb",
+				"--feature-gates=EnableIPTargetType=false",
 				"--default-tags=op-key1=op-value1,op-key2=op-value2,plat-key1=plat-value1,plat-key2=plat-value2",
 			),
 		},
@@ -217,13 +361,14 @@ func TestDesiredArgs(t *testing.T) {
 				"--disable-ingress-class-annotation",
 				"--disable-ingress-group-name-annotation",
 				"--webhook-cert-dir=/tls",
-				"--feature-gates=EnableIPTargetType=false",
 			)
 			expectedArgs := defaultArgs.Union(tc.expectedArgs)
 			if tc.controller.Spec.IngressClass == "" {
 				tc.controller.Spec.IngressClass = "alb"
 			}
-			args := desiredContainerArgs(tc.controller, "test-cluster", "test-vpc", tc.platformStatus)
+			r := &AWSLoadBalancerControllerReconciler{OperandVersion: tc.operandVersion}
+			featureGates, _ := r.desiredFeatureGates(tc.controller)
+			args := desiredContainerArgs(tc.controller, "test-cluster", "test-vpc", tc.platformStatus, featureGates)
 
 			expected := sets.List(expectedArgs)
 			sort.Strings(expected)
@@ -415,6 +560,63 @@ func TestUpdateDeployment(t *testing.T) {
 			).build(),
 			expectUpdate: true,
 		},
+		{
+			name: "bound token audience changed",
+			existingDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").build(),
+			).withVolumes(
+				testBoundTokenVolume("openshift", 3600),
+			).build(),
+			desiredDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").build(),
+			).withVolumes(
+				testBoundTokenVolume("sts.amazonaws.com", 3600),
+			).build(),
+			expectedDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").build(),
+			).withVolumes(
+				testBoundTokenVolume("sts.amazonaws.com", 3600),
+			).build(),
+			expectUpdate: true,
+		},
+		{
+			name: "bound token expiration changed",
+			existingDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").build(),
+			).withVolumes(
+				testBoundTokenVolume("sts.amazonaws.com", 3600),
+			).build(),
+			desiredDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").build(),
+			).withVolumes(
+				testBoundTokenVolume("sts.amazonaws.com", 7200),
+			).build(),
+			expectedDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").build(),
+			).withVolumes(
+				testBoundTokenVolume("sts.amazonaws.com", 7200),
+			).build(),
+			expectUpdate: true,
+		},
+		{
+			name: "bound token unchanged",
+			existingDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").build(),
+			).withVolumes(
+				testBoundTokenVolume("openshift", 3600),
+			).build(),
+			desiredDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").build(),
+			).withVolumes(
+				testBoundTokenVolume("openshift", 3600),
+			).build(),
+			expectedDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").build(),
+			).withVolumes(
+				testBoundTokenVolume("openshift", 3600),
+			).build(),
+			expectUpdate: false,
+		},
 		{
 			name: "volume mount added",
 			existingDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
@@ -542,6 +744,136 @@ func TestUpdateDeployment(t *testing.T) {
 			).withTemplateAnnotation("testannotation", "test").build(),
 			expectUpdate: false,
 		},
+		{
+			name: "container resources changed",
+			existingDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").withResources(testResources("100m", "128Mi")).build(),
+			).build(),
+			desiredDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").withResources(testResources("200m", "128Mi")).build(),
+			).build(),
+			expectedDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").withResources(testResources("200m", "128Mi")).build(),
+			).build(),
+			expectUpdate: true,
+		},
+		{
+			name: "container resources removed",
+			existingDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").withResources(testResources("100m", "128Mi")).build(),
+			).build(),
+			desiredDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").build(),
+			).build(),
+			expectedDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").build(),
+			).build(),
+			expectUpdate: true,
+		},
+		{
+			name: "container resources equal in a different notation",
+			existingDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").withResources(testResources("1", "1Gi")).build(),
+			).build(),
+			desiredDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").withResources(testResources("1000m", "1024Mi")).build(),
+			).build(),
+			expectedDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").withResources(testResources("1", "1Gi")).build(),
+			).build(),
+			expectUpdate: false,
+		},
+		{
+			name: "container resource requests defaulted to the limits",
+			existingDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").withResources(testLimitsDefaultedResources("500m", "256Mi")).build(),
+			).build(),
+			desiredDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").withResources(testLimitsOnlyResources("500m", "256Mi")).build(),
+			).build(),
+			expectedDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").withResources(testLimitsDefaultedResources("500m", "256Mi")).build(),
+			).build(),
+			expectUpdate: false,
+		},
+		{
+			name: "container resource limits changed without requests",
+			existingDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").withResources(testLimitsDefaultedResources("500m", "256Mi")).build(),
+			).build(),
+			desiredDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").withResources(testLimitsOnlyResources("1", "256Mi")).build(),
+			).build(),
+			expectedDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").withResources(testLimitsOnlyResources("1", "256Mi")).build(),
+			).build(),
+			expectUpdate: true,
+		},
+		{
+			name: "node selector changed",
+			existingDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").build(),
+			).withNodeSelector(map[string]string{"node-role.kubernetes.io/worker": ""}).build(),
+			desiredDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").build(),
+			).withNodeSelector(map[string]string{"node-role.kubernetes.io/infra": ""}).build(),
+			expectedDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").build(),
+			).withNodeSelector(map[string]string{"node-role.kubernetes.io/infra": ""}).build(),
+			expectUpdate: true,
+		},
+		{
+			name: "tolerations added",
+			existingDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").build(),
+			).build(),
+			desiredDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").build(),
+			).withTolerations(testInfraToleration()).build(),
+			expectedDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").build(),
+			).withTolerations(testInfraToleration()).build(),
+			expectUpdate: true,
+		},
+		{
+			name: "affinity removed",
+			existingDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").build(),
+			).withAffinity(testAntiAffinity("operator")).build(),
+			desiredDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").build(),
+			).build(),
+			expectedDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").build(),
+			).build(),
+			expectUpdate: true,
+		},
+		{
+			name: "scheduling constraints didn't change",
+			existingDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").build(),
+			).withNodeSelector(map[string]string{"node-role.kubernetes.io/infra": ""}).withTolerations(testInfraToleration()).withAffinity(testAntiAffinity("operator")).build(),
+			desiredDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").build(),
+			).withNodeSelector(map[string]string{"node-role.kubernetes.io/infra": ""}).withTolerations(testInfraToleration()).withAffinity(testAntiAffinity("operator")).build(),
+			expectedDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").build(),
+			).withNodeSelector(map[string]string{"node-role.kubernetes.io/infra": ""}).withTolerations(testInfraToleration()).withAffinity(testAntiAffinity("operator")).build(),
+			expectUpdate: false,
+		},
+		{
+			name: "topology spread constraints removed",
+			existingDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").build(),
+			).withTopologySpreadConstraints(testTopologySpreadConstraint("kubernetes.io/hostname", "operator")).build(),
+			desiredDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").build(),
+			).build(),
+			expectedDeployment: testDeployment("operator", "test-namespace", "test-sa", "test-serving").withContainers(
+				testContainer("controller", "controller:v1").build(),
+			).build(),
+			expectUpdate: true,
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			ctx := context.Background()
@@ -617,6 +949,54 @@ func TestEnsureDeployment(t *testing.T) {
 				}}},
 			).build(),
 		},
+		{
+			name:           "new controller with resources and scheduling constraints",
+			serviceAccount: &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "test-sa"}},
+			controller: &albo.AWSLoadBalancerController{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Spec: albo.AWSLoadBalancerControllerSpec{
+					Config: &albo.AWSLoadBalancerDeploymentConfig{
+						Replicas:     2,
+						Resources:    ptr.To(testResources("100m", "128Mi")),
+						NodeSelector: map[string]string{"node-role.kubernetes.io/infra": ""},
+						Tolerations:  []corev1.Toleration{testInfraToleration()},
+						Affinity:     testAntiAffinity("cluster"),
+					},
+				},
+			},
+			expectedDeployment: testDeployment(
+				"cluster",
+				"test-namespace",
+				"test-sa", "test-serving").withContainers(
+				testContainer("controller", "test-image").withSecurityContext(corev1.SecurityContext{
+					Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+					Privileged:               ptr.To[bool](false),
+					RunAsNonRoot:             ptr.To[bool](true),
+					AllowPrivilegeEscalation: ptr.To[bool](false),
+					SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+				}).withDefaultEnvs().withVolumeMounts(
+					corev1.VolumeMount{Name: "aws-credentials", MountPath: "/aws"},
+					corev1.VolumeMount{Name: "tls", MountPath: "/tls"},
+					corev1.VolumeMount{Name: "bound-sa-token", MountPath: "/var/run/secrets/openshift/serviceaccount", ReadOnly: true},
+				).withResources(testResources("100m", "128Mi")).build(),
+			).withControllerReference("cluster").withVolumes(
+				corev1.Volume{Name: "aws-credentials", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "test-credentials"}}},
+				corev1.Volume{Name: "tls", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "test-serving"}}},
+				corev1.Volume{Name: "bound-sa-token", VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{
+					DefaultMode: ptr.To[int32](420),
+					Sources: []corev1.VolumeProjection{{
+						ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+							Audience:          "openshift",
+							ExpirationSeconds: ptr.To[int64](3600),
+							Path:              "token",
+						},
+					}},
+				}}},
+			).withReplicas(2).withNodeSelector(map[string]string{"node-role.kubernetes.io/infra": ""}).withTolerations(testInfraToleration()).withAffinity(testAntiAffinity("cluster")).withTopologySpreadConstraints(
+				testTopologySpreadConstraint("topology.kubernetes.io/zone", "cluster"),
+				testTopologySpreadConstraint("kubernetes.io/hostname", "cluster"),
+			).build(),
+		},
 		{
 			name:           "existing controller",
 			serviceAccount: &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "test-sa"}},
@@ -824,11 +1204,18 @@ func TestEnsureDeployment(t *testing.T) {
 				VPCID:       "test-vpc",
 				AWSRegion:   testAWSRegion,
 			}
-			_, err := r.ensureDeployment(context.Background(), tc.serviceAccount, "test-credentials", "test-serving", tc.controller, nil, tc.trustedCAConfigMap)
+			credentialsSecret := testCredentialsSecret("test-credentials", "test-key")
+			_, _, err := r.ensureDeployment(context.Background(), tc.serviceAccount, credentialsSecret, "test-serving", tc.controller, nil, tc.trustedCAConfigMap)
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
-			tc.expectedDeployment.Spec.Template.Spec.Containers[0].Args = desiredContainerArgs(tc.controller, "test-cluster", "test-vpc", nil)
+			credentialsHash, _ := buildSecretHash(credentialsSecret.Data)
+			if tc.expectedDeployment.Spec.Template.Annotations == nil {
+				tc.expectedDeployment.Spec.Template.Annotations = map[string]string{}
+			}
+			tc.expectedDeployment.Spec.Template.Annotations[credentialsSecretAnnotation] = credentialsHash
+			featureGates, _ := r.desiredFeatureGates(tc.controller)
+			tc.expectedDeployment.Spec.Template.Spec.Containers[0].Args = desiredContainerArgs(tc.controller, "test-cluster", "test-vpc", nil, featureGates)
 			var deployment appsv1.Deployment
 			err = client.Get(context.Background(), types.NamespacedName{Namespace: "test-namespace", Name: fmt.Sprintf("%s-%s", controllerResourcePrefix, tc.controller.Name)}, &deployment)
 			if err != nil {
@@ -935,11 +1322,18 @@ func TestEnsureDeploymentEnvVars(t *testing.T) {
 				VPCID:       "test-vpc",
 				AWSRegion:   testAWSRegion,
 			}
-			_, err := r.ensureDeployment(context.Background(), tc.serviceAccount, "test-credentials", "test-serving", tc.controller, nil, nil)
+			credentialsSecret := testCredentialsSecret("test-credentials", "test-key")
+			_, _, err := r.ensureDeployment(context.Background(), tc.serviceAccount, credentialsSecret, "test-serving", tc.controller, nil, nil)
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
-			tc.expectedDeployment.Spec.Template.Spec.Containers[0].Args = desiredContainerArgs(tc.controller, "test-cluster", "test-vpc", nil)
+			credentialsHash, _ := buildSecretHash(credentialsSecret.Data)
+			if tc.expectedDeployment.Spec.Template.Annotations == nil {
+				tc.expectedDeployment.Spec.Template.Annotations = map[string]string{}
+			}
+			tc.expectedDeployment.Spec.Template.Annotations[credentialsSecretAnnotation] = credentialsHash
+			featureGates, _ := r.desiredFeatureGates(tc.controller)
+			tc.expectedDeployment.Spec.Template.Spec.Containers[0].Args = desiredContainerArgs(tc.controller, "test-cluster", "test-vpc", nil, featureGates)
 			var deployment appsv1.Deployment
 			err = client.Get(context.Background(), types.NamespacedName{Namespace: "test-namespace", Name: fmt.Sprintf("%s-%s", controllerResourcePrefix, tc.controller.Name)}, &deployment)
 			if err != nil {
@@ -952,6 +1346,70 @@ func TestEnsureDeploymentEnvVars(t *testing.T) {
 	}
 }
 
+func TestEnsureDeploymentCredentialsRotation(t *testing.T) {
+	currentHash, _ := buildSecretHash(testCredentialsSecret("test-credentials", "current-key").Data)
+	rotatedHash, _ := buildSecretHash(testCredentialsSecret("test-credentials", "rotated-key").Data)
+	for _, tc := range []struct {
+		name              string
+		existingObjects   []runtime.Object
+		credentialsSecret *corev1.Secret
+		expectedHash      string
+		expectedRotated   bool
+	}{
+		{
+			name:              "new deployment",
+			credentialsSecret: testCredentialsSecret("test-credentials", "current-key"),
+			expectedHash:      currentHash,
+		},
+		{
+			name: "unchanged credentials",
+			existingObjects: []runtime.Object{
+				testDeployment("cluster", "test-namespace", "test-sa", "test-serving").withTemplateAnnotation(credentialsSecretAnnotation, currentHash).build(),
+			},
+			credentialsSecret: testCredentialsSecret("test-credentials", "current-key"),
+			expectedHash:      currentHash,
+		},
+		{
+			name: "rotated credentials",
+			existingObjects: []runtime.Object{
+				testDeployment("cluster", "test-namespace", "test-sa", "test-serving").withTemplateAnnotation(credentialsSecretAnnotation, currentHash).build(),
+			},
+			credentialsSecret: testCredentialsSecret("test-credentials", "rotated-key"),
+			expectedHash:      rotatedHash,
+			expectedRotated:   true,
+		},
+		{
+			name: "deployment without credentials hash",
+			existingObjects: []runtime.Object{
+				testDeployment("cluster", "test-namespace", "test-sa", "test-serving").build(),
+			},
+			credentialsSecret: testCredentialsSecret("test-credentials", "current-key"),
+			expectedHash:      currentHash,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fake.NewClientBuilder().WithScheme(test.Scheme).WithRuntimeObjects(tc.existingObjects...).Build()
+			r := &AWSLoadBalancerControllerReconciler{
+				Client:    client,
+				Scheme:    test.Scheme,
+				Namespace: "test-namespace",
+				Image:     "test-image",
+			}
+			controller := &albo.AWSLoadBalancerController{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}}
+			deployment, rotated, err := r.ensureDeployment(context.Background(), &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "test-sa"}}, tc.credentialsSecret, "test-serving", controller, nil, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if rotated != tc.expectedRotated {
+				t.Errorf("unexpected credentials rotation, expected %t, got %t", tc.expectedRotated, rotated)
+			}
+			if hash := deployment.Spec.Template.Annotations[credentialsSecretAnnotation]; hash != tc.expectedHash {
+				t.Errorf("unexpected credentials hash annotation, expected %q, got %q", tc.expectedHash, hash)
+			}
+		})
+	}
+}
+
 func TestHasSecurityContextChanged(t *testing.T) {
 	for _, tc := range []struct {
 		name      string
@@ -1165,6 +1623,19 @@ type testDeploymentBuilder struct {
 	volumes             []corev1.Volume
 	certsSecret         string
 	templateAnnotations map[string]string
+	nodeSelector        map[string]string
+	tolerations         []corev1.Toleration
+	affinity            *corev1.Affinity
+	topologySpread      []corev1.TopologySpreadConstraint
+}
+
+func testCredentialsSecret(name, accessKeyID string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-namespace"},
+		Data: map[string][]byte{
+			"credentials": []byte(fmt.Sprintf("[default]\naws_access_key_id = %s\naws_secret_access_key = test-secret\n", accessKeyID)),
+		},
+	}
 }
 
 func testDeployment(name, namespace, serviceAccount string, certsSecret string) *testDeploymentBuilder {
@@ -1212,6 +1683,26 @@ func (b *testDeploymentBuilder) withTemplateAnnotation(k, v string) *testDeploym
 	return b
 }
 
+func (b *testDeploymentBuilder) withNodeSelector(nodeSelector map[string]string) *testDeploymentBuilder {
+	b.nodeSelector = nodeSelector
+	return b
+}
+
+func (b *testDeploymentBuilder) withTolerations(tolerations ...corev1.Toleration) *testDeploymentBuilder {
+	b.tolerations = tolerations
+	return b
+}
+
+func (b *testDeploymentBuilder) withAffinity(affinity *corev1.Affinity) *testDeploymentBuilder {
+	b.affinity = affinity
+	return b
+}
+
+func (b *testDeploymentBuilder) withTopologySpreadConstraints(constraints ...corev1.TopologySpreadConstraint) *testDeploymentBuilder {
+	b.topologySpread = constraints
+	return b
+}
+
 func (b *testDeploymentBuilder) build() *appsv1.Deployment {
 	d := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
@@ -1236,9 +1727,13 @@ func (b *testDeploymentBuilder) build() *appsv1.Deployment {
 					Annotations: b.templateAnnotations,
 				},
 				Spec: corev1.PodSpec{
-					Containers:         b.containers,
-					ServiceAccountName: b.serviceAccount,
-					Volumes:            b.volumes,
+					Containers:                b.containers,
+					ServiceAccountName:        b.serviceAccount,
+					Volumes:                   b.volumes,
+					NodeSelector:              b.nodeSelector,
+					Tolerations:               b.tolerations,
+					Affinity:                  b.affinity,
+					TopologySpreadConstraints: b.topologySpread,
 				},
 			},
 		},
@@ -1258,6 +1753,7 @@ type testContainerBuilder struct {
 	env             []corev1.EnvVar
 	volumeMounts    []corev1.VolumeMount
 	securityContext *corev1.SecurityContext
+	resources       corev1.ResourceRequirements
 }
 
 func testContainer(name, image string) *testContainerBuilder {
@@ -1300,6 +1796,11 @@ func (b *testContainerBuilder) withSecurityContext(securityContext corev1.Securi
 	return b
 }
 
+func (b *testContainerBuilder) withResources(resources corev1.ResourceRequirements) *testContainerBuilder {
+	b.resources = resources
+	return b
+}
+
 func (b *testContainerBuilder) build() corev1.Container {
 	return corev1.Container{
 		Name:            b.name,
@@ -1308,5 +1809,88 @@ func (b *testContainerBuilder) build() corev1.Container {
 		Env:             b.env,
 		VolumeMounts:    b.volumeMounts,
 		SecurityContext: b.securityContext,
+		Resources:       b.resources,
+	}
+}
+
+func testResources(cpu, memory string) corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpu),
+			corev1.ResourceMemory: resource.MustParse(memory),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse(memory),
+		},
+	}
+}
+
+func testLimitsOnlyResources(cpu, memory string) corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpu),
+			corev1.ResourceMemory: resource.MustParse(memory),
+		},
+	}
+}
+
+// testLimitsDefaultedResources returns the limits only resources
+// with the requests defaulted by the API server.
+func testLimitsDefaultedResources(cpu, memory string) corev1.ResourceRequirements {
+	resources := testLimitsOnlyResources(cpu, memory)
+	resources.Requests = resources.Limits.DeepCopy()
+	return resources
+}
+
+func testBoundTokenVolume(audience string, expirationSeconds int64) corev1.Volume {
+	return corev1.Volume{Name: "bound-sa-token", VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{
+		DefaultMode: ptr.To[int32](420),
+		Sources: []corev1.VolumeProjection{{
+			ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+				Audience:          audience,
+				ExpirationSeconds: ptr.To(expirationSeconds),
+				Path:              "token",
+			},
+		}},
+	}}}
+}
+
+func testInfraToleration() corev1.Toleration {
+	return corev1.Toleration{
+		Key:      "node-role.kubernetes.io/infra",
+		Operator: corev1.TolerationOpExists,
+		Effect:   corev1.TaintEffectNoSchedule,
+	}
+}
+
+func testAntiAffinity(instance string) *corev1.Affinity {
+	return &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+				{
+					Weight: 100,
+					PodAffinityTerm: corev1.PodAffinityTerm{
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{appInstanceName: instance},
+						},
+						TopologyKey: "kubernetes.io/hostname",
+					},
+				},
+			},
+		},
+	}
+}
+
+func testTopologySpreadConstraint(topologyKey, instance string) corev1.TopologySpreadConstraint {
+	return corev1.TopologySpreadConstraint{
+		MaxSkew:           1,
+		TopologyKey:       topologyKey,
+		WhenUnsatisfiable: corev1.ScheduleAnyway,
+		LabelSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				appInstanceName: instance,
+				appLabelName:    appName,
+			},
+		},
 	}
 }
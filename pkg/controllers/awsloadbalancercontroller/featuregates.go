@@ -0,0 +1,78 @@
+package awsloadbalancercontroller
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+
+	albo "github.com/openshift/aws-load-balancer-operator/api/v1"
+)
+
+const (
+	// ipTargetTypeFeatureGate is the feature gate managed by the targetType field.
+	ipTargetTypeFeatureGate = "EnableIPTargetType"
+)
+
+// operandFeatureGates are the feature gates of the controller which can be set through the API
+// along with the first version of the controller which supports them.
+var operandFeatureGates = map[string]*version.Version{
+	"ListenerRulesTagging":         version.MustParseSemantic("v2.2.0"),
+	"WeightedTargetGroups":         version.MustParseSemantic("v2.2.0"),
+	"ServiceTypeLoadBalancerOnly":  version.MustParseSemantic("v2.4.0"),
+	"EndpointsFailOpen":            version.MustParseSemantic("v2.4.0"),
+	"EnableServiceController":      version.MustParseSemantic("v2.4.0"),
+	"EnableRGTAPI":                 version.MustParseSemantic("v2.4.0"),
+	"SubnetsClusterTagCheck":       version.MustParseSemantic("v2.5.0"),
+	"NLBHealthCheckAdvancedConfig": version.MustParseSemantic("v2.5.0"),
+	"NLBSecurityGroup":             version.MustParseSemantic("v2.6.0"),
+	"ALBSingleSubnet":              version.MustParseSemantic("v2.7.0"),
+}
+
+// desiredFeatureGates returns the feature gates passed to the controller sorted by name
+// and the names of the requested feature gates which are not supported by the operand.
+func (r *AWSLoadBalancerControllerReconciler) desiredFeatureGates(controller *albo.AWSLoadBalancerController) (gates []string, unsupported []string) {
+	gates = append(gates, fmt.Sprintf("%s=%t", ipTargetTypeFeatureGate, ipTargetTypeEnabled(controller)))
+	for name, enabled := range controller.Spec.FeatureGates {
+		minVersion, known := operandFeatureGates[name]
+		if !known || !r.operandAtLeast(minVersion) {
+			unsupported = append(unsupported, name)
+			continue
+		}
+		gates = append(gates, fmt.Sprintf("%s=%t", name, enabled))
+	}
+	sort.Strings(gates)
+	sort.Strings(unsupported)
+	return gates, unsupported
+}
+
+// featureGatesConditions returns the condition reporting the feature gates which are not passed to the controller.
+func (r *AWSLoadBalancerControllerReconciler) featureGatesConditions(controller *albo.AWSLoadBalancerController) []metav1.Condition {
+	_, unsupported := r.desiredFeatureGates(controller)
+	if len(unsupported) == 0 {
+		return []metav1.Condition{
+			{
+				Type:               FeatureGatesValidCondition,
+				Status:             metav1.ConditionTrue,
+				ObservedGeneration: controller.Generation,
+				Reason:             "FeatureGatesSupported",
+				Message:            "All feature gates are supported by the controller",
+			},
+		}
+	}
+	operand := "the controller of unknown version"
+	if r.OperandVersion != nil {
+		operand = fmt.Sprintf("the controller version %s", r.OperandVersion)
+	}
+	return []metav1.Condition{
+		{
+			Type:               FeatureGatesValidCondition,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: controller.Generation,
+			Reason:             "UnsupportedFeatureGates",
+			Message:            fmt.Sprintf("Feature gates %s are not supported by %s and are ignored", strings.Join(unsupported, ", "), operand),
+		},
+	}
+}
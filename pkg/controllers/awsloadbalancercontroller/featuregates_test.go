@@ -0,0 +1,100 @@
+package awsloadbalancercontroller
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+
+	"github.com/google/go-cmp/cmp"
+
+	albo "github.com/openshift/aws-load-balancer-operator/api/v1"
+)
+
+func TestFeatureGatesConditions(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		featureGates   map[string]bool
+		operandVersion *version.Version
+		conditions     []metav1.Condition
+	}{
+		{
+			name: "no feature gates",
+			conditions: []metav1.Condition{
+				{
+					Type:               FeatureGatesValidCondition,
+					Status:             metav1.ConditionTrue,
+					ObservedGeneration: 2,
+					Reason:             "FeatureGatesSupported",
+					Message:            "All feature gates are supported by the controller",
+				},
+			},
+		},
+		{
+			name:           "supported feature gates",
+			featureGates:   map[string]bool{"ServiceTypeLoadBalancerOnly": true, "ListenerRulesTagging": false},
+			operandVersion: version.MustParseSemantic("v2.8.2"),
+			conditions: []metav1.Condition{
+				{
+					Type:               FeatureGatesValidCondition,
+					Status:             metav1.ConditionTrue,
+					ObservedGeneration: 2,
+					Reason:             "FeatureGatesSupported",
+					Message:            "All feature gates are supported by the controller",
+				},
+			},
+		},
+		{
+			name:           "unknown feature gates",
+			featureGates:   map[string]bool{"NotAFeatureGate": true, "EnableIPTargetType": true, "EnableRGTAPI": true},
+			operandVersion: version.MustParseSemantic("v2.8.2"),
+			conditions: []metav1.Condition{
+				{
+					Type:               FeatureGatesValidCondition,
+					Status:             metav1.ConditionFalse,
+					ObservedGeneration: 2,
+					Reason:             "UnsupportedFeatureGates",
+					Message:            "Feature gates EnableIPTargetType, NotAFeatureGate are not supported by the controller version 2.8.2 and are ignored",
+				},
+			},
+		},
+		{
+			name:           "feature gate not supported by the operand version",
+			featureGates:   map[string]bool{"NLBSecurityGroup": true, "SubnetsClusterTagCheck": true},
+			operandVersion: version.MustParseSemantic("v2.5.4"),
+			conditions: []metav1.Condition{
+				{
+					Type:               FeatureGatesValidCondition,
+					Status:             metav1.ConditionFalse,
+					ObservedGeneration: 2,
+					Reason:             "UnsupportedFeatureGates",
+					Message:            "Feature gates NLBSecurityGroup are not supported by the controller version 2.5.4 and are ignored",
+				},
+			},
+		},
+		{
+			name:         "feature gates of unknown operand version",
+			featureGates: map[string]bool{"ServiceTypeLoadBalancerOnly": true, "ListenerRulesTagging": false},
+			conditions: []metav1.Condition{
+				{
+					Type:               FeatureGatesValidCondition,
+					Status:             metav1.ConditionFalse,
+					ObservedGeneration: 2,
+					Reason:             "UnsupportedFeatureGates",
+					Message:            "Feature gates ServiceTypeLoadBalancerOnly are not supported by the controller of unknown version and are ignored",
+				},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &AWSLoadBalancerControllerReconciler{OperandVersion: tc.operandVersion}
+			controller := &albo.AWSLoadBalancerController{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster", Generation: 2},
+				Spec:       albo.AWSLoadBalancerControllerSpec{FeatureGates: tc.featureGates},
+			}
+			if diff := cmp.Diff(tc.conditions, r.featureGatesConditions(controller)); diff != "" {
+				t.Errorf("unexpected conditions (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package awsloadbalancercontroller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	arv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	albo "github.com/openshift/aws-load-balancer-operator/api/v1"
+)
+
+const (
+	// cleanupFinalizer is the finalizer which holds the deletion of the AWSLoadBalancerController
+	// until the operand is stopped and the operator's tags are removed from the subnets.
+	cleanupFinalizer = "networking.olm.openshift.io/aws-load-balancer-controller-cleanup"
+	// deploymentDeletionReEnqueueDuration is the delay to re-enqueue while waiting for the deployment deletion.
+	deploymentDeletionReEnqueueDuration = time.Second * 5
+	// securityGroupsReleaseReEnqueueDuration is the delay to re-enqueue while waiting for the security groups
+	// to be released by the network interfaces of the deleted load balancers.
+	securityGroupsReleaseReEnqueueDuration = time.Second * 30
+)
+
+// ensureFinalizer adds the cleanup finalizer to the given controller if it's not present yet.
+func (r *AWSLoadBalancerControllerReconciler) ensureFinalizer(ctx context.Context, controller *albo.AWSLoadBalancerController) error {
+	if controllerutil.ContainsFinalizer(controller, cleanupFinalizer) {
+		return nil
+	}
+	controllerutil.AddFinalizer(controller, cleanupFinalizer)
+	return r.Update(ctx, controller)
+}
+
+// finalize cleans up after the deleted controller and releases it by removing the cleanup finalizer.
+// The webhook configurations are deleted first so that the writes of the resources they intercept
+// are not blocked by the stopped operand for the whole cleanup.
+// The operand deployment is deleted next so that the operand doesn't use the subnets anymore,
+// then the load balancer resources are deleted if required by the deletion policy
+// and finally the tags put by the operator are removed from the cluster subnets.
+// The progress is reported in the Deleting condition.
+func (r *AWSLoadBalancerControllerReconciler) finalize(ctx context.Context, controller *albo.AWSLoadBalancerController) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if !controllerutil.ContainsFinalizer(controller, cleanupFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	deploymentName := fmt.Sprintf("%s-%s", controllerResourcePrefix, controller.Name)
+	// the webhook configurations have the same name as the deployment
+	if err := r.deleteWebhookConfigurations(ctx, deploymentName); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to delete webhook configurations %s: %w", deploymentName, err)
+	}
+
+	deploymentDeleted, err := r.deleteDeployment(ctx, deploymentName)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to delete deployment %s: %w", deploymentName, err)
+	}
+	if !deploymentDeleted {
+		logger.Info("(Retrying) waiting for the deployment to be deleted", "deployment", deploymentName)
+		if err := r.updateStatusConditions(ctx, controller, deletingCondition("WaitingForDeploymentDeletion", fmt.Sprintf("Waiting for deployment %q to be deleted", deploymentName), controller.Generation)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update status with deleting condition: %w", err)
+		}
+		return ctrl.Result{RequeueAfter: deploymentDeletionReEnqueueDuration}, nil
+	}
+
+	if controller.Spec.DeletionPolicy == albo.DeleteDeletionPolicy {
+		if err := r.updateStatusConditions(ctx, controller, deletingCondition("DeletingLoadBalancerResources", fmt.Sprintf("Deleting the load balancers, listeners, target groups and security groups tagged with %s=%s", elbv2ClusterTagKey, r.ClusterName), controller.Generation)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update status with deleting condition: %w", err)
+		}
+		deleted, err := r.deleteLoadBalancerResources(ctx)
+		if err != nil {
+			if updateErr := r.updateStatusConditions(ctx, controller, deletingCondition("LoadBalancerResourcesDeletionFailed", fmt.Sprintf("Failed to delete the load balancer resources: %v", err), controller.Generation)); updateErr != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to update status with deleting condition: %w", updateErr)
+			}
+			return ctrl.Result{}, fmt.Errorf("failed to delete the load balancer resources: %w", err)
+		}
+		logger.Info("deleted load balancer resources", "loadBalancers", deleted.loadBalancers, "targetGroups", deleted.targetGroups, "securityGroups", deleted.securityGroups)
+		if len(deleted.inUseSecurityGroups) > 0 {
+			logger.Info("(Retrying) waiting for the security groups to be released", "securityGroups", deleted.inUseSecurityGroups)
+			if err := r.updateStatusConditions(ctx, controller, deletingCondition("WaitingForSecurityGroupsRelease", fmt.Sprintf("Waiting for security groups %v to be released by the network interfaces of the deleted load balancers", deleted.inUseSecurityGroups), controller.Generation)); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to update status with deleting condition: %w", err)
+			}
+			return ctrl.Result{RequeueAfter: securityGroupsReleaseReEnqueueDuration}, nil
+		}
+	}
+
+	untaggedSubnets, err := r.removeClusterSubnetTags(ctx, controller)
+	if err != nil {
+		if updateErr := r.updateStatusConditions(ctx, controller, deletingCondition("SubnetTagsRemovalFailed", fmt.Sprintf("Failed to remove the operator's tags from the subnets: %v", err), controller.Generation)); updateErr != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update status with deleting condition: %w", updateErr)
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to remove the operator's tags from the subnets: %w", err)
+	}
+
+	if err := r.updateStatusConditions(ctx, controller, deletingCondition("CleanupCompleted", fmt.Sprintf("Deployment %q is deleted, the operator's tags are removed from subnets %v", deploymentName, untaggedSubnets), controller.Generation)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update status with deleting condition: %w", err)
+	}
+
+	controllerutil.RemoveFinalizer(controller, cleanupFinalizer)
+	if err := r.Update(ctx, controller); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to remove finalizer %s: %w", cleanupFinalizer, err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// deleteDeployment deletes the operand deployment and indicates whether it's gone.
+// The deployment is owned by the controller but the garbage collector doesn't remove it
+// as long as the controller is held by the finalizer.
+func (r *AWSLoadBalancerControllerReconciler) deleteDeployment(ctx context.Context, name string) (bool, error) {
+	exists, deployment, err := r.currentDeployment(ctx, name, r.Namespace)
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		return true, nil
+	}
+	if deployment.DeletionTimestamp == nil {
+		if err := r.Delete(ctx, deployment, client.PropagationPolicy(metav1.DeletePropagationForeground)); err != nil && !errors.IsNotFound(err) {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+// deleteWebhookConfigurations deletes the validating and mutating webhook configurations of the operand.
+// The configurations are owned by the controller but the garbage collector doesn't remove them
+// as long as the controller is held by the finalizer.
+func (r *AWSLoadBalancerControllerReconciler) deleteWebhookConfigurations(ctx context.Context, name string) error {
+	vwc := &arv1.ValidatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if err := r.Delete(ctx, vwc); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	mwc := &arv1.MutatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if err := r.Delete(ctx, mwc); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// removeClusterSubnetTags removes the tags put by the operator from the cluster subnets and from the subnets
+// recorded in the status, which include the subnets selected by spec.subnets without the cluster tag.
+// The ids of the untagged subnets are returned. The role tags put by the user are kept.
+func (r *AWSLoadBalancerControllerReconciler) removeClusterSubnetTags(ctx context.Context, controller *albo.AWSLoadBalancerController) ([]string, error) {
+	subnets, err := r.describeClusterSubnets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if statusSubnets := controller.Status.Subnets; statusSubnets != nil {
+		ids := sets.New(statusSubnets.Tagged...).Insert(statusSubnets.Public...).Insert(statusSubnets.Internal...).Insert(statusSubnets.Untagged...)
+		recorded, err := r.describeSubnetsByID(ctx, sets.List(ids.Delete(sets.List(subnetIDs(subnets))...)))
+		if err != nil {
+			return nil, err
+		}
+		subnets = mergeSubnets(subnets, recorded)
+	}
+	// the conflicting subnets are not taken into account as the role tag put by the operator is unknown
+	_, _, tagged, _, _ := classifySubnets(subnets)
+	if tagged.Len() == 0 {
+		return nil, nil
+	}
+	if err := r.removeOperatorTags(ctx, sets.List(tagged)); err != nil {
+		return nil, err
+	}
+	return sets.List(tagged), nil
+}
+
+// deletingCondition returns the condition reporting the progress of the cleanup of the deleted controller.
+func deletingCondition(reason, message string, generation int64) metav1.Condition {
+	return metav1.Condition{
+		Type:               DeletingCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	}
+}
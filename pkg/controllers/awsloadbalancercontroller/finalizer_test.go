@@ -0,0 +1,295 @@
+package awsloadbalancercontroller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	arv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/aws/smithy-go"
+
+	albo "github.com/openshift/aws-load-balancer-operator/api/v1"
+	"github.com/openshift/aws-load-balancer-operator/pkg/utils"
+	"github.com/openshift/aws-load-balancer-operator/pkg/utils/test"
+)
+
+func TestEnsureFinalizer(t *testing.T) {
+	controller := testALBC(albo.AutoSubnetTaggingPolicy)
+	r := &AWSLoadBalancerControllerReconciler{
+		Client: fake.NewClientBuilder().WithScheme(test.Scheme).WithObjects(controller).Build(),
+	}
+	if err := r.ensureFinalizer(context.Background(), controller); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// the finalizer is added only once
+	if err := r.ensureFinalizer(context.Background(), controller); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	updated, _, err := r.getAWSLoadBalancerController(context.Background(), controller.Name)
+	if err != nil {
+		t.Fatalf("failed to get the controller: %v", err)
+	}
+	if !utils.EqualStrings([]string{cleanupFinalizer}, updated.Finalizers) {
+		t.Errorf("expected finalizers %v, got %v", []string{cleanupFinalizer}, updated.Finalizers)
+	}
+}
+
+func TestFinalize(t *testing.T) {
+	const deploymentName = "aws-load-balancer-controller-cluster"
+	for _, tc := range []struct {
+		name                        string
+		deploymentExists            bool
+		webhooksExist               bool
+		currentSubnets              []ec2types.Subnet
+		accountSubnets              []ec2types.Subnet
+		statusSubnets               *albo.AWSLoadBalancerControllerStatusSubnets
+		expectedRequeue             bool
+		expectedRemoveTagOperations []string
+		expectedReleased            bool
+		deletionPolicy              albo.DeletionPolicy
+		loadBalancers               []elbv2types.LoadBalancer
+		securityGroups              []ec2types.SecurityGroup
+		failingDeletions            map[string]error
+		expectedOperations          []string
+		expectedError               string
+		expectedConditionReason     string
+	}{
+		{
+			name:             "webhooks and deployment are deleted first",
+			deploymentExists: true,
+			webhooksExist:    true,
+			currentSubnets: []ec2types.Subnet{
+				testSubnet("subnet-1", publicELBTagKey, tagKeyALBOTagged),
+			},
+			expectedRequeue:         true,
+			expectedConditionReason: "WaitingForDeploymentDeletion",
+		},
+		{
+			name: "operator tags are removed once the deployment is gone",
+			currentSubnets: []ec2types.Subnet{
+				testSubnet("subnet-1", publicELBTagKey, tagKeyALBOTagged),
+				testSubnet("subnet-2", internalELBTagKey, tagKeyALBOTagged),
+				testSubnet("subnet-3", publicELBTagKey),
+				testSubnet("subnet-4", publicELBTagKey, internalELBTagKey, tagKeyALBOTagged),
+				testSubnet("subnet-5"),
+			},
+			expectedRemoveTagOperations: []string{"subnet-1", "subnet-2"},
+			expectedReleased:            true,
+		},
+		{
+			name: "operator tags are removed from the subnets recorded in the status",
+			currentSubnets: []ec2types.Subnet{
+				testSubnet("subnet-1", publicELBTagKey, tagKeyALBOTagged),
+			},
+			// subnet-2 is selected by spec.subnets without the cluster tag, subnet-3 was deleted
+			accountSubnets: []ec2types.Subnet{
+				testSubnet("subnet-1", publicELBTagKey, tagKeyALBOTagged),
+				testSubnet("subnet-2", internalELBTagKey, tagKeyALBOTagged),
+			},
+			statusSubnets: &albo.AWSLoadBalancerControllerStatusSubnets{
+				Public:   []string{"subnet-1"},
+				Internal: []string{"subnet-2", "subnet-3"},
+				Tagged:   []string{"subnet-1", "subnet-2", "subnet-3"},
+			},
+			expectedRemoveTagOperations: []string{"subnet-1", "subnet-2"},
+			expectedReleased:            true,
+		},
+		{
+			name:           "load balancer resources are deleted with delete policy",
+			deletionPolicy: albo.DeleteDeletionPolicy,
+			currentSubnets: []ec2types.Subnet{
+				testSubnet("subnet-1", publicELBTagKey, tagKeyALBOTagged),
+			},
+			loadBalancers: []elbv2types.LoadBalancer{
+				testLoadBalancer("lb-1", "test-vpc"),
+			},
+			expectedOperations:          []string{"DeleteLoadBalancer lb-1"},
+			expectedRemoveTagOperations: []string{"subnet-1"},
+			expectedReleased:            true,
+		},
+		{
+			name:           "load balancer resources are retained by default",
+			deletionPolicy: albo.RetainDeletionPolicy,
+			currentSubnets: []ec2types.Subnet{
+				testSubnet("subnet-1", publicELBTagKey, tagKeyALBOTagged),
+			},
+			loadBalancers: []elbv2types.LoadBalancer{
+				testLoadBalancer("lb-1", "test-vpc"),
+			},
+			expectedRemoveTagOperations: []string{"subnet-1"},
+			expectedReleased:            true,
+		},
+		{
+			name:           "load balancer resources deletion failure is reported",
+			deletionPolicy: albo.DeleteDeletionPolicy,
+			currentSubnets: []ec2types.Subnet{
+				testSubnet("subnet-1", publicELBTagKey, tagKeyALBOTagged),
+			},
+			loadBalancers: []elbv2types.LoadBalancer{
+				testLoadBalancer("lb-1", "test-vpc"),
+			},
+			failingDeletions: map[string]error{
+				"lb-1": errors.New("AccessDenied"),
+			},
+			expectedError:           "failed to delete the load balancer resources: failed to delete load balancer lb-1: AccessDenied",
+			expectedConditionReason: "LoadBalancerResourcesDeletionFailed",
+		},
+		{
+			name:           "security groups in use are waited for",
+			deletionPolicy: albo.DeleteDeletionPolicy,
+			currentSubnets: []ec2types.Subnet{
+				testSubnet("subnet-1", publicELBTagKey, tagKeyALBOTagged),
+			},
+			loadBalancers: []elbv2types.LoadBalancer{
+				testLoadBalancer("lb-1", "test-vpc"),
+			},
+			securityGroups: []ec2types.SecurityGroup{
+				testSecurityGroup("sg-1", "test-vpc", "test-cluster"),
+			},
+			failingDeletions: map[string]error{
+				"sg-1": &smithy.GenericAPIError{Code: "DependencyViolation"},
+			},
+			expectedOperations:      []string{"DeleteLoadBalancer lb-1"},
+			expectedRequeue:         true,
+			expectedConditionReason: "WaitingForSecurityGroupsRelease",
+		},
+		{
+			name:          "webhooks are deleted before the load balancer resources",
+			webhooksExist: true,
+			currentSubnets: []ec2types.Subnet{
+				testSubnet("subnet-1", publicELBTagKey, tagKeyALBOTagged),
+			},
+			expectedRemoveTagOperations: []string{"subnet-1"},
+			expectedReleased:            true,
+		},
+		{
+			name: "no subnet tagged by the operator",
+			currentSubnets: []ec2types.Subnet{
+				testSubnet("subnet-1", publicELBTagKey),
+			},
+			expectedReleased: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := testALBC(albo.AutoSubnetTaggingPolicy)
+			controller.Finalizers = []string{cleanupFinalizer}
+			controller.DeletionTimestamp = &metav1.Time{}
+			controller.Spec.DeletionPolicy = tc.deletionPolicy
+			controller.Status.Subnets = tc.statusSubnets
+			objects := []client.Object{controller}
+			if tc.deploymentExists {
+				objects = append(objects, &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Name: deploymentName, Namespace: "test-namespace"},
+				})
+			}
+			if tc.webhooksExist {
+				objects = append(objects,
+					&arv1.ValidatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: deploymentName}},
+					&arv1.MutatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: deploymentName}},
+				)
+			}
+			cl := fake.NewClientBuilder().WithScheme(test.Scheme).WithStatusSubresource(controller).WithObjects(objects...).Build()
+			ec2Client := &testEC2Client{
+				t:              t,
+				subnets:        tc.currentSubnets,
+				accountSubnets: tc.accountSubnets,
+				clusterID:      "test-cluster",
+				vpcID:          "test-vpc",
+			}
+			var operations []string
+			tags := map[string]string{}
+			for _, lb := range tc.loadBalancers {
+				tags[*lb.LoadBalancerArn] = "test-cluster"
+			}
+			r := &AWSLoadBalancerControllerReconciler{
+				Client:    cl,
+				Namespace: "test-namespace",
+				EC2Client: &testSecurityGroupEC2Client{
+					testEC2Client:    ec2Client,
+					securityGroups:   tc.securityGroups,
+					failingDeletions: tc.failingDeletions,
+					operations:       &operations,
+				},
+				ELBv2Client: &testELBv2Client{
+					t:                t,
+					loadBalancers:    tc.loadBalancers,
+					clusterTags:      tags,
+					failingDeletions: tc.failingDeletions,
+					operations:       &operations,
+				},
+				ClusterName: "test-cluster",
+				VPCID:       "test-vpc",
+			}
+			current, _, err := r.getAWSLoadBalancerController(context.Background(), controller.Name)
+			if err != nil {
+				t.Fatalf("failed to get the controller: %v", err)
+			}
+
+			result, err := r.finalize(context.Background(), current)
+			if tc.expectedError != "" {
+				if err == nil || err.Error() != tc.expectedError {
+					t.Errorf("expected error %q, got %v", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !utils.EqualStrings(tc.expectedOperations, operations) {
+				t.Errorf("expected operations %v, got %v", tc.expectedOperations, operations)
+			}
+			if tc.expectedRequeue != (result.RequeueAfter > 0) {
+				t.Errorf("expected requeue to be %t, got result %v", tc.expectedRequeue, result)
+			}
+			if !sets.New[string](tc.expectedRemoveTagOperations...).Equal(sets.New[string](ec2Client.untaggedResources...)) {
+				t.Errorf("expected subnets %v to have been untagged, instead got %v", tc.expectedRemoveTagOperations, ec2Client.untaggedResources)
+			}
+
+			if tc.deploymentExists {
+				var deployment appsv1.Deployment
+				if err := cl.Get(context.Background(), types.NamespacedName{Name: deploymentName, Namespace: "test-namespace"}, &deployment); err == nil {
+					t.Errorf("expected deployment %s to be deleted", deploymentName)
+				}
+			}
+
+			if tc.webhooksExist {
+				if err := cl.Get(context.Background(), types.NamespacedName{Name: deploymentName}, &arv1.ValidatingWebhookConfiguration{}); err == nil {
+					t.Errorf("expected validating webhook configuration %s to be deleted", deploymentName)
+				}
+				if err := cl.Get(context.Background(), types.NamespacedName{Name: deploymentName}, &arv1.MutatingWebhookConfiguration{}); err == nil {
+					t.Errorf("expected mutating webhook configuration %s to be deleted", deploymentName)
+				}
+			}
+
+			updated, exists, err := r.getAWSLoadBalancerController(context.Background(), controller.Name)
+			if err != nil {
+				t.Fatalf("failed to get the controller: %v", err)
+			}
+			if !exists {
+				t.Fatalf("controller %s not found", controller.Name)
+			}
+			if released := len(updated.Finalizers) == 0; released != tc.expectedReleased {
+				t.Errorf("expected the controller release to be %t, got finalizers %v", tc.expectedReleased, updated.Finalizers)
+			}
+			if tc.expectedReleased {
+				return
+			}
+			var reason string
+			for _, cond := range updated.Status.Conditions {
+				if cond.Type == DeletingCondition {
+					reason = cond.Reason
+				}
+			}
+			if reason != tc.expectedConditionReason {
+				t.Errorf("expected %s condition with reason %q, got %q", DeletingCondition, tc.expectedConditionReason, reason)
+			}
+		})
+	}
+}
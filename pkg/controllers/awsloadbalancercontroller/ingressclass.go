@@ -3,10 +3,14 @@ package awsloadbalancercontroller
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
@@ -15,50 +19,300 @@ import (
 
 const (
 	albIngressClassController = "ingress.k8s.aws/alb"
+	// defaultIngressClassAnnotationKey marks the IngressClass assigned to the Ingresses without a class.
+	defaultIngressClassAnnotationKey = "ingressclass.kubernetes.io/is-default-class"
 )
 
-// ensureIngressClass create the default IngressClass which is specified in the controller. This is required because the OpenShift router
+// ensureIngressClass creates the IngressClasses which are specified in the controller. This is required because the OpenShift router
 // reconciles any Ingress resource whose class is not defined or if the IngressClass does not have the spec.controllerName set.
-// Steps to ensure the IngressClass
-// 1. Check the status to see if the IngressClass already exists
-// 2. If the name matches then do nothing.
-// 3. If the name does not match then delete the existing IngressClass. Ignore if it doesn't exist.
-// 4. Create the new IngresClass with the correct controller name. If there is an AlreadyExists error, ignore it.
-func (r *AWSLoadBalancerControllerReconciler) ensureIngressClass(ctx context.Context, controller *albo.AWSLoadBalancerController) error {
-
-	// if the current ingress class is the same then do nothing.
-	if controller.Status.IngressClass == controller.Spec.IngressClass {
-		return nil
+// Steps to ensure the IngressClasses
+// 1. Delete the IngressClasses from the status which are no longer in the spec along with their IngressClassParams. Ignore if they don't exist or belong to another controller.
+// 2. Unmark the default IngressClass if another IngressClass is already the default class of the cluster.
+// 3. Ensure the IngressClassParams of each IngressClass.
+// 4. Create the new IngresClasses with the correct controller name and the reference to the IngressClassParams.
+// 5. Recreate the IngressClasses owned by the operator whose controller name was changed, the field is immutable.
+// 6. Update the parameters and the default class annotation of the existing IngressClasses which belong to the controller.
+// An IngressClass of another controller is left untouched, its name is returned in the conflicting IngressClasses.
+// The other IngressClasses marked as the default class of the cluster are returned in the conflicting default IngressClasses.
+// An IngressClassParams not owned by the controller is left untouched, its name is returned in the conflicting IngressClassParams.
+func (r *AWSLoadBalancerControllerReconciler) ensureIngressClass(ctx context.Context, controller *albo.AWSLoadBalancerController) ([]string, []string, []string, error) {
+	desired := desiredIngressClasses(controller)
+	desiredNames := make(map[string]struct{}, len(desired))
+	for _, class := range desired {
+		desiredNames[class.Name] = struct{}{}
+	}
+
+	// delete the ingress classes which were removed from the spec.
+	for _, name := range append([]string{controller.Status.IngressClass}, controller.Status.IngressClasses...) {
+		if _, found := desiredNames[name]; found || name == "" {
+			continue
+		}
+		if err := r.deleteIngressClass(ctx, controller, name); err != nil {
+			return nil, nil, nil, err
+		}
+		if err := r.deleteIngressClassParams(ctx, controller, name); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	var conflictingDefaults []string
+	if defaultIngressClassName(desired) != "" {
+		var err error
+		conflictingDefaults, err = r.otherDefaultIngressClasses(ctx, desiredNames)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		// multiple default classes prevent the creation of the Ingresses without a class.
+		if len(conflictingDefaults) > 0 {
+			for i := range desired {
+				desired[i].Default = false
+			}
+		}
+	}
+
+	var conflicting, conflictingParams []string
+	for i := range desired {
+		conflict, paramsConflict, err := r.ensureSingleIngressClass(ctx, controller, &desired[i])
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if conflict {
+			conflicting = append(conflicting, desired[i].Name)
+		}
+		if paramsConflict {
+			conflictingParams = append(conflictingParams, desired[i].Name)
+		}
+	}
+	sort.Strings(conflicting)
+	sort.Strings(conflictingParams)
+	return conflicting, conflictingDefaults, conflictingParams, nil
+}
+
+// otherDefaultIngressClasses returns the sorted names of the IngressClasses marked as the default class of the cluster
+// which are not in the given IngressClasses.
+func (r *AWSLoadBalancerControllerReconciler) otherDefaultIngressClasses(ctx context.Context, names map[string]struct{}) ([]string, error) {
+	var ingressClasses networkingv1.IngressClassList
+	if err := r.List(ctx, &ingressClasses); err != nil {
+		return nil, fmt.Errorf("failed to list IngressClasses: %w", err)
 	}
+	var defaults []string
+	for _, ingressClass := range ingressClasses.Items {
+		if _, found := names[ingressClass.Name]; found {
+			continue
+		}
+		if ingressClass.Annotations[defaultIngressClassAnnotationKey] == "true" {
+			defaults = append(defaults, ingressClass.Name)
+		}
+	}
+	sort.Strings(defaults)
+	return defaults, nil
+}
 
-	// if the current ingress class name does not match then delete it.
-	if controller.Status.IngressClass != "" {
-		err := r.Delete(ctx, &networkingv1.IngressClass{ObjectMeta: metav1.ObjectMeta{Name: controller.Status.IngressClass}})
-		if err != nil && !errors.IsNotFound(err) {
-			return fmt.Errorf("failed to delete existing IngressClass %q: %w", controller.Status.IngressClass, err)
+// ensureSingleIngressClass ensures the given IngressClass and its IngressClassParams.
+// Returns true if the IngressClass already exists and belongs to another controller
+// and whether the IngressClassParams already exists and is not owned by the controller.
+func (r *AWSLoadBalancerControllerReconciler) ensureSingleIngressClass(ctx context.Context, controller *albo.AWSLoadBalancerController, class *albo.AWSLoadBalancerIngressClass) (bool, bool, error) {
+	var current networkingv1.IngressClass
+	err := r.Get(ctx, types.NamespacedName{Name: class.Name}, &current)
+	if err != nil && !errors.IsNotFound(err) {
+		return false, false, fmt.Errorf("failed to get existing IngressClass %q: %w", class.Name, err)
+	}
+	exists := err == nil
+	if exists && current.Spec.Controller != albIngressClassController {
+		if !metav1.IsControlledBy(&current, controller) {
+			return true, false, nil
 		}
+		// the controller name is immutable, the drifted IngressClass is recreated.
+		if err := r.Delete(ctx, &current); err != nil && !errors.IsNotFound(err) {
+			return false, false, fmt.Errorf("failed to delete IngressClass %q with controller %q: %w", class.Name, current.Spec.Controller, err)
+		}
+		exists = false
+	}
+
+	paramsRef, paramsConflict, err := r.ensureIngressClassParams(ctx, controller, class.Name, class.IngressClassParams)
+	if err != nil {
+		return false, false, err
 	}
 
-	ingressClass := desiredIngressClass(controller.Spec.IngressClass)
-	err := controllerutil.SetControllerReference(controller, ingressClass, r.Scheme)
+	ingressClass := desiredIngressClass(class.Name, paramsRef, class.Default)
+	err = controllerutil.SetControllerReference(controller, ingressClass, r.Scheme)
 	if err != nil {
-		return fmt.Errorf("failed to set owner reference on new IngressClass %q: %w", ingressClass.Name, err)
+		return false, false, fmt.Errorf("failed to set owner reference on new IngressClass %q: %w", ingressClass.Name, err)
 	}
 
-	err = r.Create(ctx, ingressClass)
-	if err != nil && !errors.IsAlreadyExists(err) {
-		return fmt.Errorf("failed to create default IngressClass %s: %w", controller.Spec.IngressClass, err)
+	if !exists {
+		if err := r.Create(ctx, ingressClass); err != nil {
+			return false, false, fmt.Errorf("failed to create IngressClass %s: %w", ingressClass.Name, err)
+		}
+		return false, paramsConflict, nil
+	}
+	return false, paramsConflict, r.updateIngressClass(ctx, &current, ingressClass)
+}
+
+// deleteIngressClass deletes the IngressClass with the given name if it's owned by the controller.
+// The IngressClasses which were in conflict with another controller are reported in the status but are not owned by the controller.
+func (r *AWSLoadBalancerControllerReconciler) deleteIngressClass(ctx context.Context, controller *albo.AWSLoadBalancerController, name string) error {
+	var current networkingv1.IngressClass
+	if err := r.Get(ctx, types.NamespacedName{Name: name}, &current); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get existing IngressClass %q: %w", name, err)
+	}
+	if !metav1.IsControlledBy(&current, controller) {
+		return nil
+	}
+	if err := r.Delete(ctx, &current); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete existing IngressClass %q: %w", name, err)
 	}
 	return nil
 }
 
-func desiredIngressClass(name string) *networkingv1.IngressClass {
-	return &networkingv1.IngressClass{
+// ingressClassConditions returns the condition reporting the IngressClasses which belong to another controller
+// and the IngressClassParams which are not owned by the controller.
+func ingressClassConditions(conflicting, conflictingParams []string, generation int64) []metav1.Condition {
+	if len(conflicting) == 0 && len(conflictingParams) == 0 {
+		return []metav1.Condition{
+			{
+				Type:               IngressClassAvailableCondition,
+				Status:             metav1.ConditionTrue,
+				ObservedGeneration: generation,
+				Reason:             "IngressClassesAvailable",
+				Message:            "All IngressClasses belong to the controller",
+			},
+		}
+	}
+	if len(conflicting) == 0 {
+		return []metav1.Condition{
+			{
+				Type:               IngressClassAvailableCondition,
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: generation,
+				Reason:             "IngressClassParamsConflict",
+				Message:            fmt.Sprintf("IngressClassParams %s already exist and are not owned by the controller", strings.Join(conflictingParams, ", ")),
+			},
+		}
+	}
+	message := fmt.Sprintf("IngressClasses %s already exist and belong to another controller", strings.Join(conflicting, ", "))
+	if len(conflictingParams) > 0 {
+		message += fmt.Sprintf("; IngressClassParams %s already exist and are not owned by the controller", strings.Join(conflictingParams, ", "))
+	}
+	return []metav1.Condition{
+		{
+			Type:               IngressClassAvailableCondition,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: generation,
+			Reason:             "IngressClassConflict",
+			Message:            message,
+		},
+	}
+}
+
+// defaultIngressClassConditions returns the condition reporting the other IngressClasses which are already
+// the default class of the cluster when an IngressClass of the controller is requested to be the default class.
+func defaultIngressClassConditions(controller *albo.AWSLoadBalancerController, conflictingDefaults []string) []metav1.Condition {
+	name := defaultIngressClassName(desiredIngressClasses(controller))
+	if name == "" {
+		return []metav1.Condition{
+			{
+				Type:               DefaultIngressClassConflictCondition,
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: controller.Generation,
+				Reason:             "DefaultIngressClassNotRequested",
+				Message:            "No IngressClass of the controller is requested to be the default class",
+			},
+		}
+	}
+	if len(conflictingDefaults) == 0 {
+		return []metav1.Condition{
+			{
+				Type:               DefaultIngressClassConflictCondition,
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: controller.Generation,
+				Reason:             "DefaultIngressClassMarked",
+				Message:            fmt.Sprintf("IngressClass %q is the default class", name),
+			},
+		}
+	}
+	return []metav1.Condition{
+		{
+			Type:               DefaultIngressClassConflictCondition,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: controller.Generation,
+			Reason:             "DefaultIngressClassExists",
+			Message:            fmt.Sprintf("IngressClass %q is not marked as the default class because IngressClasses %s are already the default class", name, strings.Join(conflictingDefaults, ", ")),
+		},
+	}
+}
+
+// desiredIngressClasses returns the IngressClasses of the controller: ingressClass followed by ingressClasses.
+func desiredIngressClasses(controller *albo.AWSLoadBalancerController) []albo.AWSLoadBalancerIngressClass {
+	classes := []albo.AWSLoadBalancerIngressClass{
+		{
+			Name:               controller.Spec.IngressClass,
+			IngressClassParams: controller.Spec.IngressClassParams,
+			Default:            controller.Spec.DefaultIngressClass,
+		},
+	}
+	return append(classes, controller.Spec.IngressClasses...)
+}
+
+// defaultIngressClassName returns the name of the IngressClass requested to be the default class of the cluster,
+// empty if none is.
+func defaultIngressClassName(classes []albo.AWSLoadBalancerIngressClass) string {
+	for _, class := range classes {
+		if class.Default {
+			return class.Name
+		}
+	}
+	return ""
+}
+
+// ingressClassNames returns the sorted names of the IngressClasses of the controller.
+func ingressClassNames(controller *albo.AWSLoadBalancerController) []string {
+	var names []string
+	for _, class := range desiredIngressClasses(controller) {
+		names = append(names, class.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func desiredIngressClass(name string, parameters *networkingv1.IngressClassParametersReference, isDefault bool) *networkingv1.IngressClass {
+	ingressClass := &networkingv1.IngressClass{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: name,
 		},
 		Spec: networkingv1.IngressClassSpec{
 			Controller: albIngressClassController,
+			Parameters: parameters,
 		},
 	}
+	if isDefault {
+		ingressClass.Annotations = map[string]string{defaultIngressClassAnnotationKey: "true"}
+	}
+	return ingressClass
+}
+
+// updateIngressClass updates the parameters and the default class annotation of the current IngressClass
+// if they differ from the desired ones.
+func (r *AWSLoadBalancerControllerReconciler) updateIngressClass(ctx context.Context, current, desired *networkingv1.IngressClass) error {
+	currentDefault, desiredDefault := current.Annotations[defaultIngressClassAnnotationKey], desired.Annotations[defaultIngressClassAnnotationKey]
+	if equality.Semantic.DeepEqual(current.Spec.Parameters, desired.Spec.Parameters) && currentDefault == desiredDefault {
+		return nil
+	}
+	updated := current.DeepCopy()
+	updated.Spec.Parameters = desired.Spec.Parameters
+	if desiredDefault != "" {
+		if updated.Annotations == nil {
+			updated.Annotations = map[string]string{}
+		}
+		updated.Annotations[defaultIngressClassAnnotationKey] = desiredDefault
+	} else {
+		delete(updated.Annotations, defaultIngressClassAnnotationKey)
+	}
+	if err := r.Update(ctx, updated); err != nil {
+		return fmt.Errorf("failed to update IngressClass %q: %w", current.Name, err)
+	}
+	return nil
 }
@@ -8,30 +8,81 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 
+	"github.com/google/go-cmp/cmp"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	elbv2v1beta1 "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+
 	albo "github.com/openshift/aws-load-balancer-operator/api/v1"
 	"github.com/openshift/aws-load-balancer-operator/pkg/utils/test"
 )
 
 func TestDesiredIngressClass(t *testing.T) {
-	ic := desiredIngressClass("test")
+	ic := desiredIngressClass("test", nil, false)
 	if ic.Name != "test" {
 		t.Errorf("unexpected name in desired ingress class, expected %q, got %q", "test", ic.Name)
 	}
 	if ic.Spec.Controller != albIngressClassController {
 		t.Errorf("unexpected controller in desired ingress class, expected %q, got %q", albIngressClassController, ic.Spec.Controller)
 	}
+	if ic.Spec.Parameters != nil {
+		t.Errorf("unexpected parameters in desired ingress class: %v", ic.Spec.Parameters)
+	}
+}
+
+func testIngressClassParamsRef(name string) *networkingv1.IngressClassParametersReference {
+	return &networkingv1.IngressClassParametersReference{
+		APIGroup: ptr.To("elbv2.k8s.aws"),
+		Kind:     "IngressClassParams",
+		Name:     name,
+	}
+}
+
+func testIngressClassParams(name string, scheme elbv2v1beta1.LoadBalancerScheme, owned bool) *elbv2v1beta1.IngressClassParams {
+	icp := &elbv2v1beta1.IngressClassParams{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: elbv2v1beta1.IngressClassParamsSpec{
+			Scheme: ptr.To(scheme),
+		},
+	}
+	if owned {
+		icp.OwnerReferences = testIngressClassOwnerReferences()
+	}
+	return icp
+}
+
+func testOwnedIngressClass(name string, parameters *networkingv1.IngressClassParametersReference) *networkingv1.IngressClass {
+	ingressClass := desiredIngressClass(name, parameters, false)
+	ingressClass.OwnerReferences = testIngressClassOwnerReferences()
+	return ingressClass
+}
+
+func testIngressClassOwnerReferences() []metav1.OwnerReference {
+	return []metav1.OwnerReference{
+		{
+			APIVersion: "networking.olm.openshift.io/v1",
+			Kind:       "AWSLoadBalancerController",
+			Name:       "test",
+			UID:        "test-uid",
+			Controller: ptr.To(true),
+		},
+	}
 }
 
 func TestEnsureIngressClass(t *testing.T) {
 	for _, tc := range []struct {
 		name                 string
 		existingIngressClass *networkingv1.IngressClass
+		existingObjects      []client.Object
 		ingressClassName     string
+		ingressClassParams   *albo.AWSLoadBalancerIngressClassParams
 		deletedIngressClass  bool
+		expectedParameters   *networkingv1.IngressClassParametersReference
+		expectedParamsSpec   *elbv2v1beta1.IngressClassParamsSpec
+		deletedParams        string
 	}{
 		{
 			name:             "no existing ingress class",
@@ -39,25 +90,108 @@ func TestEnsureIngressClass(t *testing.T) {
 		},
 		{
 			name:                 "existing ingress class",
-			existingIngressClass: desiredIngressClass("old"),
+			existingIngressClass: testOwnedIngressClass("old", nil),
 			ingressClassName:     "new",
 			deletedIngressClass:  true,
 		},
 		{
 			name:                 "existing ingress class, name no change",
-			existingIngressClass: desiredIngressClass("old"),
+			existingIngressClass: desiredIngressClass("old", nil, false),
 			ingressClassName:     "old",
 		},
+		{
+			name:             "new ingress class with params",
+			ingressClassName: "new",
+			ingressClassParams: &albo.AWSLoadBalancerIngressClassParams{
+				Scheme:        albo.InternalLoadBalancerScheme,
+				IPAddressType: albo.DualStackLoadBalancerIPAddressType,
+				Group:         "internal",
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"alb": "internal"},
+				},
+				Tags:                   []albo.AWSResourceTag{{Key: "team", Value: "network"}},
+				LoadBalancerAttributes: []albo.AWSLoadBalancerAttribute{{Key: "idle_timeout.timeout_seconds", Value: "120"}},
+				SSLPolicy:              "ELBSecurityPolicy-TLS13-1-2-2021-06",
+			},
+			expectedParameters: testIngressClassParamsRef("new"),
+			expectedParamsSpec: &elbv2v1beta1.IngressClassParamsSpec{
+				Scheme:        ptr.To(elbv2v1beta1.LoadBalancerSchemeInternal),
+				IPAddressType: ptr.To(elbv2v1beta1.IPAddressTypeDualStack),
+				Group:         &elbv2v1beta1.IngressGroup{Name: "internal"},
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"alb": "internal"},
+				},
+				Tags:                   []elbv2v1beta1.Tag{{Key: "team", Value: "network"}},
+				LoadBalancerAttributes: []elbv2v1beta1.Attribute{{Key: "idle_timeout.timeout_seconds", Value: "120"}},
+				SSLPolicy:              "ELBSecurityPolicy-TLS13-1-2-2021-06",
+			},
+		},
+		{
+			name:                 "params added to existing ingress class",
+			existingIngressClass: desiredIngressClass("old", nil, false),
+			ingressClassName:     "old",
+			ingressClassParams:   &albo.AWSLoadBalancerIngressClassParams{Scheme: albo.InternetFacingLoadBalancerScheme},
+			expectedParameters:   testIngressClassParamsRef("old"),
+			expectedParamsSpec: &elbv2v1beta1.IngressClassParamsSpec{
+				Scheme: ptr.To(elbv2v1beta1.LoadBalancerSchemeInternetFacing),
+			},
+		},
+		{
+			name:                 "drifted params",
+			existingIngressClass: desiredIngressClass("old", testIngressClassParamsRef("old"), false),
+			existingObjects: []client.Object{
+				testIngressClassParams("old", elbv2v1beta1.LoadBalancerSchemeInternetFacing, true),
+			},
+			ingressClassName:   "old",
+			ingressClassParams: &albo.AWSLoadBalancerIngressClassParams{Scheme: albo.InternalLoadBalancerScheme},
+			expectedParameters: testIngressClassParamsRef("old"),
+			expectedParamsSpec: &elbv2v1beta1.IngressClassParamsSpec{
+				Scheme: ptr.To(elbv2v1beta1.LoadBalancerSchemeInternal),
+			},
+		},
+		{
+			name:                 "params removed",
+			existingIngressClass: desiredIngressClass("old", testIngressClassParamsRef("old"), false),
+			existingObjects: []client.Object{
+				testIngressClassParams("old", elbv2v1beta1.LoadBalancerSchemeInternal, true),
+			},
+			ingressClassName: "old",
+			deletedParams:    "old",
+		},
+		{
+			name:                 "params of renamed ingress class",
+			existingIngressClass: testOwnedIngressClass("old", testIngressClassParamsRef("old")),
+			existingObjects: []client.Object{
+				testIngressClassParams("old", elbv2v1beta1.LoadBalancerSchemeInternal, true),
+			},
+			ingressClassName:    "new",
+			ingressClassParams:  &albo.AWSLoadBalancerIngressClassParams{Scheme: albo.InternalLoadBalancerScheme},
+			deletedIngressClass: true,
+			expectedParameters:  testIngressClassParamsRef("new"),
+			expectedParamsSpec: &elbv2v1beta1.IngressClassParamsSpec{
+				Scheme: ptr.To(elbv2v1beta1.LoadBalancerSchemeInternal),
+			},
+			deletedParams: "old",
+		},
+		{
+			name:                 "params not owned by the controller are kept",
+			existingIngressClass: desiredIngressClass("old", testIngressClassParamsRef("old"), false),
+			existingObjects: []client.Object{
+				testIngressClassParams("old", elbv2v1beta1.LoadBalancerSchemeInternal, false),
+			},
+			ingressClassName: "old",
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
-			var existingObjects []client.Object
+			existingObjects := append([]client.Object{}, tc.existingObjects...)
 			if tc.existingIngressClass != nil {
 				existingObjects = append(existingObjects, tc.existingIngressClass)
 			}
 			controller := &albo.AWSLoadBalancerController{
-				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				ObjectMeta: metav1.ObjectMeta{Name: "test", UID: "test-uid"},
 				Spec: albo.AWSLoadBalancerControllerSpec{
-					IngressClass: tc.ingressClassName,
+					IngressClass:       tc.ingressClassName,
+					IngressClassParams: tc.ingressClassParams,
 				},
 			}
 			if tc.existingIngressClass != nil {
@@ -69,7 +203,7 @@ func TestEnsureIngressClass(t *testing.T) {
 				Scheme: test.Scheme,
 				Client: testClient,
 			}
-			err := r.ensureIngressClass(context.Background(), controller)
+			_, _, _, err := r.ensureIngressClass(context.Background(), controller)
 			if err != nil {
 				t.Errorf("unexpected error: %v", err)
 				return
@@ -82,6 +216,39 @@ func TestEnsureIngressClass(t *testing.T) {
 			if ingressClass.Spec.Controller != albIngressClassController {
 				t.Errorf("IngressClass does not have correct controller name, expected %q, got %q", albIngressClassController, ingressClass.Spec.Controller)
 			}
+			if tc.ingressClassParams != nil || tc.deletedParams != "" {
+				if diff := cmp.Diff(tc.expectedParameters, ingressClass.Spec.Parameters); diff != "" {
+					t.Errorf("unexpected IngressClass parameters (-want +got):\n%s", diff)
+				}
+			}
+			if tc.expectedParamsSpec != nil {
+				var params elbv2v1beta1.IngressClassParams
+				if err := testClient.Get(context.Background(), types.NamespacedName{Name: tc.ingressClassName}, &params); err != nil {
+					t.Fatalf("failed to get ingress class params %q: %v", tc.ingressClassName, err)
+				}
+				if diff := cmp.Diff(*tc.expectedParamsSpec, params.Spec); diff != "" {
+					t.Errorf("unexpected IngressClassParams spec (-want +got):\n%s", diff)
+				}
+				if !metav1.IsControlledBy(&params, controller) {
+					t.Errorf("expected IngressClassParams to be owned by the controller, got %v", params.OwnerReferences)
+				}
+			}
+			if tc.deletedParams != "" {
+				var params elbv2v1beta1.IngressClassParams
+				err = testClient.Get(context.Background(), types.NamespacedName{Name: tc.deletedParams}, &params)
+				if !errors.IsNotFound(err) {
+					t.Errorf("expected ingress class params %q to be deleted, got %v", tc.deletedParams, err)
+				}
+			}
+			for _, obj := range tc.existingObjects {
+				params, ok := obj.(*elbv2v1beta1.IngressClassParams)
+				if !ok || len(params.OwnerReferences) != 0 {
+					continue
+				}
+				if err := testClient.Get(context.Background(), types.NamespacedName{Name: params.Name}, &elbv2v1beta1.IngressClassParams{}); err != nil {
+					t.Errorf("expected ingress class params %q to be kept, got %v", params.Name, err)
+				}
+			}
 			if tc.deletedIngressClass {
 				var ic networkingv1.IngressClass
 				err = r.Get(context.Background(), types.NamespacedName{Name: tc.existingIngressClass.Name}, &ic)
@@ -96,3 +263,395 @@ func TestEnsureIngressClass(t *testing.T) {
 		})
 	}
 }
+
+func TestEnsureMultipleIngressClasses(t *testing.T) {
+	for _, tc := range []struct {
+		name                        string
+		ingressClasses              []albo.AWSLoadBalancerIngressClass
+		statusIngressClasses        []string
+		existingObjects             []client.Object
+		expectedIngressClass        map[string]*networkingv1.IngressClass
+		expectedParamsSchemes       map[string]elbv2v1beta1.LoadBalancerScheme
+		deletedIngressClasses       []string
+		expectedConflicting         []string
+		defaultIngressClass         bool
+		expectedConflictingDefaults []string
+		expectedConflictingParams   []string
+	}{
+		{
+			name: "ingress class params not owned by the controller kept",
+			ingressClasses: []albo.AWSLoadBalancerIngressClass{
+				{
+					Name: "alb-internal",
+					IngressClassParams: &albo.AWSLoadBalancerIngressClassParams{
+						Scheme: albo.InternalLoadBalancerScheme,
+					},
+				},
+			},
+			existingObjects: []client.Object{
+				testIngressClassParams("alb-internal", elbv2v1beta1.LoadBalancerSchemeInternetFacing, false),
+			},
+			expectedIngressClass: map[string]*networkingv1.IngressClass{
+				"alb-internal": desiredIngressClass("alb-internal", nil, false),
+			},
+			expectedParamsSchemes: map[string]elbv2v1beta1.LoadBalancerScheme{
+				"alb-internal": elbv2v1beta1.LoadBalancerSchemeInternetFacing,
+			},
+			expectedConflictingParams: []string{"alb-internal"},
+		},
+		{
+			name: "additional ingress classes created",
+			ingressClasses: []albo.AWSLoadBalancerIngressClass{
+				{
+					Name: "alb-internal",
+					IngressClassParams: &albo.AWSLoadBalancerIngressClassParams{
+						Scheme: albo.InternalLoadBalancerScheme,
+					},
+				},
+				{
+					Name:    "alb-public",
+					Default: true,
+				},
+			},
+			expectedIngressClass: map[string]*networkingv1.IngressClass{
+				"alb":          desiredIngressClass("alb", nil, false),
+				"alb-internal": desiredIngressClass("alb-internal", testIngressClassParamsRef("alb-internal"), false),
+				"alb-public":   desiredIngressClass("alb-public", nil, true),
+			},
+			expectedParamsSchemes: map[string]elbv2v1beta1.LoadBalancerScheme{
+				"alb-internal": elbv2v1beta1.LoadBalancerSchemeInternal,
+			},
+		},
+		{
+			name:                 "removed ingress class deleted",
+			statusIngressClasses: []string{"alb", "alb-internal"},
+			existingObjects: []client.Object{
+				desiredIngressClass("alb", nil, false),
+				testOwnedIngressClass("alb-internal", testIngressClassParamsRef("alb-internal")),
+				testIngressClassParams("alb-internal", elbv2v1beta1.LoadBalancerSchemeInternal, true),
+			},
+			expectedIngressClass: map[string]*networkingv1.IngressClass{
+				"alb": desiredIngressClass("alb", nil, false),
+			},
+			deletedIngressClasses: []string{"alb-internal"},
+		},
+		{
+			name:                 "removed ingress class of another controller kept",
+			statusIngressClasses: []string{"alb", "nginx"},
+			existingObjects: []client.Object{
+				desiredIngressClass("alb", nil, false),
+				&networkingv1.IngressClass{
+					ObjectMeta: metav1.ObjectMeta{Name: "nginx"},
+					Spec:       networkingv1.IngressClassSpec{Controller: "k8s.io/ingress-nginx"},
+				},
+			},
+			expectedIngressClass: map[string]*networkingv1.IngressClass{
+				"alb": desiredIngressClass("alb", nil, false),
+				"nginx": {
+					Spec: networkingv1.IngressClassSpec{Controller: "k8s.io/ingress-nginx"},
+				},
+			},
+		},
+		{
+			name: "default annotation added",
+			ingressClasses: []albo.AWSLoadBalancerIngressClass{
+				{
+					Name:    "alb-public",
+					Default: true,
+				},
+			},
+			statusIngressClasses: []string{"alb", "alb-public"},
+			existingObjects: []client.Object{
+				desiredIngressClass("alb", nil, false),
+				desiredIngressClass("alb-public", nil, false),
+			},
+			expectedIngressClass: map[string]*networkingv1.IngressClass{
+				"alb":        desiredIngressClass("alb", nil, false),
+				"alb-public": desiredIngressClass("alb-public", nil, true),
+			},
+		},
+		{
+			name: "ingress class of another controller",
+			ingressClasses: []albo.AWSLoadBalancerIngressClass{
+				{
+					Name: "nginx",
+				},
+			},
+			existingObjects: []client.Object{
+				&networkingv1.IngressClass{
+					ObjectMeta: metav1.ObjectMeta{Name: "nginx"},
+					Spec:       networkingv1.IngressClassSpec{Controller: "k8s.io/ingress-nginx"},
+				},
+			},
+			expectedIngressClass: map[string]*networkingv1.IngressClass{
+				"alb": desiredIngressClass("alb", nil, false),
+				"nginx": {
+					Spec: networkingv1.IngressClassSpec{Controller: "k8s.io/ingress-nginx"},
+				},
+			},
+			expectedConflicting: []string{"nginx"},
+		},
+		{
+			name: "drifted controller name repaired",
+			existingObjects: []client.Object{
+				&networkingv1.IngressClass{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "alb",
+						OwnerReferences: testIngressClassOwnerReferences(),
+					},
+					Spec: networkingv1.IngressClassSpec{Controller: "openshift.io/ingress-to-route"},
+				},
+			},
+			expectedIngressClass: map[string]*networkingv1.IngressClass{
+				"alb": desiredIngressClass("alb", nil, false),
+			},
+		},
+		{
+			name:                 "deleted ingress class recreated",
+			statusIngressClasses: []string{"alb", "alb-public"},
+			ingressClasses: []albo.AWSLoadBalancerIngressClass{
+				{
+					Name: "alb-public",
+				},
+			},
+			existingObjects: []client.Object{
+				desiredIngressClass("alb", nil, false),
+			},
+			expectedIngressClass: map[string]*networkingv1.IngressClass{
+				"alb":        desiredIngressClass("alb", nil, false),
+				"alb-public": desiredIngressClass("alb-public", nil, false),
+			},
+		},
+		{
+			name:                "ingress class marked as default",
+			defaultIngressClass: true,
+			existingObjects: []client.Object{
+				desiredIngressClass("alb", nil, false),
+				&networkingv1.IngressClass{
+					ObjectMeta: metav1.ObjectMeta{Name: "openshift-default"},
+					Spec:       networkingv1.IngressClassSpec{Controller: "openshift.io/ingress-to-route"},
+				},
+			},
+			expectedIngressClass: map[string]*networkingv1.IngressClass{
+				"alb": desiredIngressClass("alb", nil, true),
+			},
+		},
+		{
+			name:                "another ingress class is already default",
+			defaultIngressClass: true,
+			existingObjects: []client.Object{
+				desiredIngressClass("alb", nil, false),
+				&networkingv1.IngressClass{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "openshift-default",
+						Annotations: map[string]string{defaultIngressClassAnnotationKey: "true"},
+					},
+					Spec: networkingv1.IngressClassSpec{Controller: "openshift.io/ingress-to-route"},
+				},
+			},
+			expectedIngressClass: map[string]*networkingv1.IngressClass{
+				"alb": desiredIngressClass("alb", nil, false),
+			},
+			expectedConflictingDefaults: []string{"openshift-default"},
+		},
+		{
+			name: "default annotation removed",
+			ingressClasses: []albo.AWSLoadBalancerIngressClass{
+				{
+					Name: "alb-public",
+				},
+			},
+			statusIngressClasses: []string{"alb", "alb-public"},
+			existingObjects: []client.Object{
+				desiredIngressClass("alb", nil, false),
+				desiredIngressClass("alb-public", nil, true),
+			},
+			expectedIngressClass: map[string]*networkingv1.IngressClass{
+				"alb":        desiredIngressClass("alb", nil, false),
+				"alb-public": desiredIngressClass("alb-public", nil, false),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := &albo.AWSLoadBalancerController{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", UID: "test-uid"},
+				Spec: albo.AWSLoadBalancerControllerSpec{
+					IngressClass:        "alb",
+					IngressClasses:      tc.ingressClasses,
+					DefaultIngressClass: tc.defaultIngressClass,
+				},
+				Status: albo.AWSLoadBalancerControllerStatus{
+					IngressClass:   "alb",
+					IngressClasses: tc.statusIngressClasses,
+				},
+			}
+			existingObjects := append([]client.Object{controller}, tc.existingObjects...)
+			testClient := fake.NewClientBuilder().WithScheme(test.Scheme).WithObjects(existingObjects...).Build()
+			r := &AWSLoadBalancerControllerReconciler{
+				Scheme: test.Scheme,
+				Client: testClient,
+			}
+			conflicting, conflictingDefaults, conflictingParams, err := r.ensureIngressClass(context.Background(), controller)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expectedConflicting, conflicting); diff != "" {
+				t.Errorf("unexpected conflicting ingress classes (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedConflictingDefaults, conflictingDefaults); diff != "" {
+				t.Errorf("unexpected conflicting default ingress classes (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedConflictingParams, conflictingParams); diff != "" {
+				t.Errorf("unexpected conflicting ingress class params (-want +got):\n%s", diff)
+			}
+			for name, expected := range tc.expectedIngressClass {
+				var ingressClass networkingv1.IngressClass
+				if err := testClient.Get(context.Background(), types.NamespacedName{Name: name}, &ingressClass); err != nil {
+					t.Fatalf("failed to get ingress class %q: %v", name, err)
+				}
+				if diff := cmp.Diff(expected.Spec, ingressClass.Spec); diff != "" {
+					t.Errorf("unexpected spec of ingress class %q (-want +got):\n%s", name, diff)
+				}
+				if diff := cmp.Diff(expected.Annotations[defaultIngressClassAnnotationKey], ingressClass.Annotations[defaultIngressClassAnnotationKey]); diff != "" {
+					t.Errorf("unexpected default annotation of ingress class %q (-want +got):\n%s", name, diff)
+				}
+			}
+			for name, scheme := range tc.expectedParamsSchemes {
+				var params elbv2v1beta1.IngressClassParams
+				if err := testClient.Get(context.Background(), types.NamespacedName{Name: name}, &params); err != nil {
+					t.Fatalf("failed to get ingress class params %q: %v", name, err)
+				}
+				if diff := cmp.Diff(ptr.To(scheme), params.Spec.Scheme); diff != "" {
+					t.Errorf("unexpected scheme of ingress class params %q (-want +got):\n%s", name, diff)
+				}
+			}
+			for _, name := range tc.deletedIngressClasses {
+				if err := testClient.Get(context.Background(), types.NamespacedName{Name: name}, &networkingv1.IngressClass{}); !errors.IsNotFound(err) {
+					t.Errorf("expected ingress class %q to be deleted, got %v", name, err)
+				}
+				if err := testClient.Get(context.Background(), types.NamespacedName{Name: name}, &elbv2v1beta1.IngressClassParams{}); !errors.IsNotFound(err) {
+					t.Errorf("expected ingress class params %q to be deleted, got %v", name, err)
+				}
+			}
+		})
+	}
+}
+
+func TestIngressClassConditions(t *testing.T) {
+	for _, tc := range []struct {
+		name              string
+		conflicting       []string
+		conflictingParams []string
+		expectedStatus    metav1.ConditionStatus
+		expectedReason    string
+		expectedMessage   string
+	}{
+		{
+			name:            "no conflict",
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  "IngressClassesAvailable",
+			expectedMessage: "All IngressClasses belong to the controller",
+		},
+		{
+			name:            "conflicting ingress classes",
+			conflicting:     []string{"alb", "nginx"},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  "IngressClassConflict",
+			expectedMessage: "IngressClasses alb, nginx already exist and belong to another controller",
+		},
+		{
+			name:              "conflicting ingress class params",
+			conflictingParams: []string{"alb-internal"},
+			expectedStatus:    metav1.ConditionFalse,
+			expectedReason:    "IngressClassParamsConflict",
+			expectedMessage:   "IngressClassParams alb-internal already exist and are not owned by the controller",
+		},
+		{
+			name:              "conflicting ingress classes and params",
+			conflicting:       []string{"nginx"},
+			conflictingParams: []string{"alb-internal"},
+			expectedStatus:    metav1.ConditionFalse,
+			expectedReason:    "IngressClassConflict",
+			expectedMessage:   "IngressClasses nginx already exist and belong to another controller; IngressClassParams alb-internal already exist and are not owned by the controller",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			conditions := ingressClassConditions(tc.conflicting, tc.conflictingParams, 2)
+			if len(conditions) != 1 {
+				t.Fatalf("expected 1 condition, got %d", len(conditions))
+			}
+			cond := conditions[0]
+			if cond.Type != IngressClassAvailableCondition || cond.Status != tc.expectedStatus || cond.Reason != tc.expectedReason || cond.Message != tc.expectedMessage || cond.ObservedGeneration != 2 {
+				t.Errorf("unexpected condition: %+v", cond)
+			}
+		})
+	}
+}
+
+func TestDefaultIngressClassConditions(t *testing.T) {
+	for _, tc := range []struct {
+		name                string
+		defaultIngressClass bool
+		conflictingDefaults []string
+		expectedStatus      metav1.ConditionStatus
+		expectedReason      string
+		expectedMessage     string
+	}{
+		{
+			name:            "default not requested",
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  "DefaultIngressClassNotRequested",
+			expectedMessage: "No IngressClass of the controller is requested to be the default class",
+		},
+		{
+			name:                "default marked",
+			defaultIngressClass: true,
+			expectedStatus:      metav1.ConditionFalse,
+			expectedReason:      "DefaultIngressClassMarked",
+			expectedMessage:     `IngressClass "alb" is the default class`,
+		},
+		{
+			name:                "another default ingress class",
+			defaultIngressClass: true,
+			conflictingDefaults: []string{"nginx", "openshift-default"},
+			expectedStatus:      metav1.ConditionTrue,
+			expectedReason:      "DefaultIngressClassExists",
+			expectedMessage:     `IngressClass "alb" is not marked as the default class because IngressClasses nginx, openshift-default are already the default class`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := &albo.AWSLoadBalancerController{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Generation: 2},
+				Spec: albo.AWSLoadBalancerControllerSpec{
+					IngressClass:        "alb",
+					DefaultIngressClass: tc.defaultIngressClass,
+				},
+			}
+			conditions := defaultIngressClassConditions(controller, tc.conflictingDefaults)
+			if len(conditions) != 1 {
+				t.Fatalf("expected 1 condition, got %d", len(conditions))
+			}
+			cond := conditions[0]
+			if cond.Type != DefaultIngressClassConflictCondition || cond.Status != tc.expectedStatus || cond.Reason != tc.expectedReason || cond.Message != tc.expectedMessage || cond.ObservedGeneration != 2 {
+				t.Errorf("unexpected condition: %+v", cond)
+			}
+		})
+	}
+}
+
+func TestIngressClassNames(t *testing.T) {
+	controller := &albo.AWSLoadBalancerController{
+		Spec: albo.AWSLoadBalancerControllerSpec{
+			IngressClass: "alb",
+			IngressClasses: []albo.AWSLoadBalancerIngressClass{
+				{Name: "internal"},
+				{Name: "public"},
+				{Name: "alb-extra"},
+			},
+		},
+	}
+	expected := []string{"alb", "alb-extra", "internal", "public"}
+	if diff := cmp.Diff(expected, ingressClassNames(controller)); diff != "" {
+		t.Errorf("unexpected ingress class names (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,119 @@
+package awsloadbalancercontroller
+
+import (
+	"context"
+	"fmt"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	elbv2v1beta1 "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
+
+	albo "github.com/openshift/aws-load-balancer-operator/api/v1"
+)
+
+const (
+	ingressClassParamsAPIGroup = "elbv2.k8s.aws"
+	ingressClassParamsKind     = "IngressClassParams"
+)
+
+// ensureIngressClassParams ensures the IngressClassParams with the given name matches the given params.
+// The returned reference is meant to be set in the IngressClass, it's nil when the params are not specified.
+// In this case the IngressClassParams previously created by the operator is removed.
+// An existing IngressClassParams which is not owned by the controller is left untouched and not referenced,
+// true is returned to report the conflict.
+func (r *AWSLoadBalancerControllerReconciler) ensureIngressClassParams(ctx context.Context, controller *albo.AWSLoadBalancerController, name string, params *albo.AWSLoadBalancerIngressClassParams) (*networkingv1.IngressClassParametersReference, bool, error) {
+	if params == nil {
+		return nil, false, r.deleteIngressClassParams(ctx, controller, name)
+	}
+
+	desired := desiredIngressClassParams(name, params)
+	if err := controllerutil.SetControllerReference(controller, desired, r.Scheme); err != nil {
+		return nil, false, fmt.Errorf("failed to set owner reference on desired IngressClassParams %q: %w", name, err)
+	}
+
+	var current elbv2v1beta1.IngressClassParams
+	if err := r.Get(ctx, types.NamespacedName{Name: name}, &current); err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, false, fmt.Errorf("failed to get existing IngressClassParams %q: %w", name, err)
+		}
+		if err := r.Create(ctx, desired); err != nil {
+			return nil, false, fmt.Errorf("failed to create IngressClassParams %q: %w", name, err)
+		}
+	} else {
+		if !metav1.IsControlledBy(&current, controller) {
+			return nil, true, nil
+		}
+		if err := r.updateIngressClassParams(ctx, &current, desired); err != nil {
+			return nil, false, fmt.Errorf("failed to update IngressClassParams %q: %w", name, err)
+		}
+	}
+
+	return &networkingv1.IngressClassParametersReference{
+		APIGroup: ptr.To(ingressClassParamsAPIGroup),
+		Kind:     ingressClassParamsKind,
+		Name:     name,
+	}, false, nil
+}
+
+// deleteIngressClassParams deletes the IngressClassParams with the given name if it's owned by the controller.
+func (r *AWSLoadBalancerControllerReconciler) deleteIngressClassParams(ctx context.Context, controller *albo.AWSLoadBalancerController, name string) error {
+	var current elbv2v1beta1.IngressClassParams
+	if err := r.Get(ctx, types.NamespacedName{Name: name}, &current); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get existing IngressClassParams %q: %w", name, err)
+	}
+	if !metav1.IsControlledBy(&current, controller) {
+		return nil
+	}
+	if err := r.Delete(ctx, &current); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete IngressClassParams %q: %w", name, err)
+	}
+	return nil
+}
+
+func desiredIngressClassParams(name string, params *albo.AWSLoadBalancerIngressClassParams) *elbv2v1beta1.IngressClassParams {
+	icp := &elbv2v1beta1.IngressClassParams{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: elbv2v1beta1.IngressClassParamsSpec{
+			NamespaceSelector: params.NamespaceSelector.DeepCopy(),
+			SSLPolicy:         params.SSLPolicy,
+		},
+	}
+	if params.Scheme != "" {
+		icp.Spec.Scheme = ptr.To(elbv2v1beta1.LoadBalancerScheme(params.Scheme))
+	}
+	if params.IPAddressType != "" {
+		icp.Spec.IPAddressType = ptr.To(elbv2v1beta1.IPAddressType(params.IPAddressType))
+	}
+	if params.Group != "" {
+		icp.Spec.Group = &elbv2v1beta1.IngressGroup{Name: params.Group}
+	}
+	for _, tag := range params.Tags {
+		icp.Spec.Tags = append(icp.Spec.Tags, elbv2v1beta1.Tag{Key: tag.Key, Value: tag.Value})
+	}
+	for _, attr := range params.LoadBalancerAttributes {
+		icp.Spec.LoadBalancerAttributes = append(icp.Spec.LoadBalancerAttributes, elbv2v1beta1.Attribute{Key: attr.Key, Value: attr.Value})
+	}
+	return icp
+}
+
+// updateIngressClassParams overwrites the spec of the current IngressClassParams if it drifted from the desired one.
+func (r *AWSLoadBalancerControllerReconciler) updateIngressClassParams(ctx context.Context, current, desired *elbv2v1beta1.IngressClassParams) error {
+	if equality.Semantic.DeepEqual(current.Spec, desired.Spec) {
+		return nil
+	}
+	updated := current.DeepCopy()
+	updated.Spec = desired.Spec
+	return r.Update(ctx, updated)
+}
@@ -0,0 +1,128 @@
+package awsloadbalancercontroller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	awstypes "github.com/aws/aws-sdk-go-v2/aws"
+
+	albo "github.com/openshift/aws-load-balancer-operator/api/v1"
+	"github.com/openshift/aws-load-balancer-operator/pkg/aws"
+)
+
+const (
+	// loadBalancerInventoryInterval is the interval between two inventories of the load balancers.
+	loadBalancerInventoryInterval = 5 * time.Minute
+	// loadBalancerInventoryRetryInterval is the interval between a failed inventory and the next one.
+	loadBalancerInventoryRetryInterval = time.Minute
+	// ingressStackTagKey is the tag put by the controller on the load balancers of the Ingresses.
+	// Its value is the namespaced name of the Ingress or the name of the IngressGroup.
+	ingressStackTagKey = "ingress.k8s.aws/stack"
+	// serviceStackTagKey is the tag put by the controller on the load balancers of the Services.
+	// Its value is the namespaced name of the Service.
+	serviceStackTagKey = "service.k8s.aws/stack"
+)
+
+// syncLoadBalancers lists the load balancers provisioned by the controller for the cluster
+// and reports them in the status. The inventory is done at most once per interval,
+// the duration until the next inventory is returned. The failures of the inventory are reported
+// in the LoadBalancersInventoried condition and don't fail the reconciliation,
+// the load balancers of the last inventory are kept and the failed inventory is retried after a shorter interval.
+func (r *AWSLoadBalancerControllerReconciler) syncLoadBalancers(ctx context.Context, controller *albo.AWSLoadBalancerController) (synced bool, nextSync time.Duration, err error) {
+	if sinceLastSync, interval := time.Since(r.loadBalancersSyncedAt), r.nextLoadBalancerInventoryInterval(); sinceLastSync < interval {
+		return false, interval - sinceLastSync, nil
+	}
+
+	statuses := controller.Status.LoadBalancers
+	loadBalancers, inventoryErr := aws.GetClusterLoadBalancers(ctx, r.ELBv2Client, r.ClusterName, r.VPCID)
+	if inventoryErr != nil {
+		log.FromContext(ctx).Info("failed to list the load balancers of the cluster", "cluster", r.ClusterName, "reason", inventoryErr)
+	} else {
+		statuses = buildLoadBalancerStatuses(loadBalancers)
+	}
+
+	if err := r.updateStatusLoadBalancers(ctx, controller, statuses, loadBalancersInventoryConditions(inventoryErr, controller.Generation)...); err != nil {
+		return false, 0, fmt.Errorf("failed to update status with load balancers: %w", err)
+	}
+
+	r.loadBalancersSyncedAt, r.loadBalancersSyncFailed = time.Now(), inventoryErr != nil
+	return true, r.nextLoadBalancerInventoryInterval(), nil
+}
+
+// nextLoadBalancerInventoryInterval returns the interval between the last load balancer inventory and the next one.
+func (r *AWSLoadBalancerControllerReconciler) nextLoadBalancerInventoryInterval() time.Duration {
+	if r.loadBalancersSyncFailed {
+		return loadBalancerInventoryRetryInterval
+	}
+	return loadBalancerInventoryInterval
+}
+
+// loadBalancersInventoryConditions returns the condition reporting whether the load balancers could be listed.
+func loadBalancersInventoryConditions(inventoryErr error, generation int64) []metav1.Condition {
+	if inventoryErr != nil {
+		return []metav1.Condition{
+			{
+				Type:               LoadBalancersInventoriedCondition,
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: generation,
+				Reason:             "InventoryFailed",
+				Message:            fmt.Sprintf("Failed to list the load balancers of the cluster: %v", inventoryErr),
+			},
+		}
+	}
+	return []metav1.Condition{
+		{
+			Type:               LoadBalancersInventoriedCondition,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: generation,
+			Reason:             "InventoryCompleted",
+			Message:            "The load balancers of the cluster are listed in the status",
+		},
+	}
+}
+
+// buildLoadBalancerStatuses returns the statuses of the given load balancers sorted by ARN.
+func buildLoadBalancerStatuses(loadBalancers []aws.LoadBalancer) []albo.AWSLoadBalancerStatus {
+	var statuses []albo.AWSLoadBalancerStatus
+	for _, lb := range loadBalancers {
+		status := albo.AWSLoadBalancerStatus{
+			ARN:     awstypes.ToString(lb.LoadBalancerArn),
+			DNSName: awstypes.ToString(lb.DNSName),
+			Scheme:  string(lb.Scheme),
+			Type:    string(lb.Type),
+			Source:  loadBalancerSource(lb.Tags),
+		}
+		if lb.State != nil {
+			status.State = string(lb.State.Code)
+		}
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].ARN < statuses[j].ARN
+	})
+	return statuses
+}
+
+// loadBalancerSource returns the resource for which a load balancer was provisioned
+// from the stack tag put by the controller. The Ingresses of an IngressGroup share
+// the load balancer whose stack tag is the name of the group.
+func loadBalancerSource(tags map[string]string) *albo.LoadBalancerSource {
+	if stack, ok := tags[ingressStackTagKey]; ok {
+		if namespace, name, found := strings.Cut(stack, "/"); found {
+			return &albo.LoadBalancerSource{Kind: albo.IngressLoadBalancerSourceKind, Namespace: namespace, Name: name}
+		}
+		return &albo.LoadBalancerSource{Kind: albo.IngressGroupLoadBalancerSourceKind, Name: stack}
+	}
+	if stack, ok := tags[serviceStackTagKey]; ok {
+		if namespace, name, found := strings.Cut(stack, "/"); found {
+			return &albo.LoadBalancerSource{Kind: albo.ServiceLoadBalancerSourceKind, Namespace: namespace, Name: name}
+		}
+	}
+	return nil
+}
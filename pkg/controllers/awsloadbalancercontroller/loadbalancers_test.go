@@ -0,0 +1,237 @@
+package awsloadbalancercontroller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	awstypes "github.com/aws/aws-sdk-go-v2/aws"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	albo "github.com/openshift/aws-load-balancer-operator/api/v1"
+	"github.com/openshift/aws-load-balancer-operator/pkg/utils/test"
+)
+
+func TestSyncLoadBalancers(t *testing.T) {
+	for _, tc := range []struct {
+		name                    string
+		statusLoadBalancers     []albo.AWSLoadBalancerStatus
+		sinceLastSync           time.Duration
+		lastSyncFailed          bool
+		loadBalancers           []elbv2types.LoadBalancer
+		describeErr             error
+		clusterTags             map[string]string
+		tags                    map[string]map[string]string
+		expectedSynced          bool
+		expectedLoadBalancers   []albo.AWSLoadBalancerStatus
+		expectedConditionStatus metav1.ConditionStatus
+		expectedNextSyncAtMost  time.Duration
+	}{
+		{
+			name: "first inventory",
+			loadBalancers: []elbv2types.LoadBalancer{
+				testActiveLoadBalancer("lb-service", "test-vpc", elbv2types.LoadBalancerSchemeEnumInternal, elbv2types.LoadBalancerTypeEnumNetwork),
+				testActiveLoadBalancer("lb-ingress", "test-vpc", elbv2types.LoadBalancerSchemeEnumInternetFacing, elbv2types.LoadBalancerTypeEnumApplication),
+				testActiveLoadBalancer("lb-group", "test-vpc", elbv2types.LoadBalancerSchemeEnumInternetFacing, elbv2types.LoadBalancerTypeEnumApplication),
+				testActiveLoadBalancer("lb-other-cluster", "test-vpc", elbv2types.LoadBalancerSchemeEnumInternetFacing, elbv2types.LoadBalancerTypeEnumApplication),
+				testActiveLoadBalancer("lb-other-vpc", "other-vpc", elbv2types.LoadBalancerSchemeEnumInternetFacing, elbv2types.LoadBalancerTypeEnumApplication),
+			},
+			clusterTags: map[string]string{
+				"lb-service":       "test-cluster",
+				"lb-ingress":       "test-cluster",
+				"lb-group":         "test-cluster",
+				"lb-other-cluster": "other-cluster",
+				"lb-other-vpc":     "test-cluster",
+			},
+			tags: map[string]map[string]string{
+				"lb-service": {serviceStackTagKey: "test-ns/test-service"},
+				"lb-ingress": {ingressStackTagKey: "test-ns/test-ingress"},
+				"lb-group":   {ingressStackTagKey: "test-group"},
+			},
+			expectedSynced: true,
+			expectedLoadBalancers: []albo.AWSLoadBalancerStatus{
+				{
+					ARN:     "lb-group",
+					DNSName: "lb-group.elb.amazonaws.com",
+					Scheme:  "internet-facing",
+					Type:    "application",
+					State:   "active",
+					Source:  &albo.LoadBalancerSource{Kind: albo.IngressGroupLoadBalancerSourceKind, Name: "test-group"},
+				},
+				{
+					ARN:     "lb-ingress",
+					DNSName: "lb-ingress.elb.amazonaws.com",
+					Scheme:  "internet-facing",
+					Type:    "application",
+					State:   "active",
+					Source:  &albo.LoadBalancerSource{Kind: albo.IngressLoadBalancerSourceKind, Namespace: "test-ns", Name: "test-ingress"},
+				},
+				{
+					ARN:     "lb-service",
+					DNSName: "lb-service.elb.amazonaws.com",
+					Scheme:  "internal",
+					Type:    "network",
+					State:   "active",
+					Source:  &albo.LoadBalancerSource{Kind: albo.ServiceLoadBalancerSourceKind, Namespace: "test-ns", Name: "test-service"},
+				},
+			},
+			expectedConditionStatus: metav1.ConditionTrue,
+			expectedNextSyncAtMost:  loadBalancerInventoryInterval,
+		},
+		{
+			name: "load balancer without stack tag",
+			loadBalancers: []elbv2types.LoadBalancer{
+				testActiveLoadBalancer("lb-1", "test-vpc", elbv2types.LoadBalancerSchemeEnumInternal, elbv2types.LoadBalancerTypeEnumApplication),
+			},
+			clusterTags: map[string]string{
+				"lb-1": "test-cluster",
+			},
+			expectedSynced: true,
+			expectedLoadBalancers: []albo.AWSLoadBalancerStatus{
+				{
+					ARN:     "lb-1",
+					DNSName: "lb-1.elb.amazonaws.com",
+					Scheme:  "internal",
+					Type:    "application",
+					State:   "active",
+				},
+			},
+			expectedConditionStatus: metav1.ConditionTrue,
+			expectedNextSyncAtMost:  loadBalancerInventoryInterval,
+		},
+		{
+			name: "deleted load balancers removed from status",
+			statusLoadBalancers: []albo.AWSLoadBalancerStatus{
+				{ARN: "lb-1", State: "active"},
+			},
+			sinceLastSync:           loadBalancerInventoryInterval,
+			expectedSynced:          true,
+			expectedConditionStatus: metav1.ConditionTrue,
+			expectedNextSyncAtMost:  loadBalancerInventoryInterval,
+		},
+		{
+			name: "inventory interval not elapsed",
+			statusLoadBalancers: []albo.AWSLoadBalancerStatus{
+				{ARN: "lb-1", State: "active"},
+			},
+			sinceLastSync: time.Minute,
+			loadBalancers: []elbv2types.LoadBalancer{
+				testActiveLoadBalancer("lb-2", "test-vpc", elbv2types.LoadBalancerSchemeEnumInternal, elbv2types.LoadBalancerTypeEnumApplication),
+			},
+			clusterTags: map[string]string{
+				"lb-2": "test-cluster",
+			},
+			expectedLoadBalancers: []albo.AWSLoadBalancerStatus{
+				{ARN: "lb-1", State: "active"},
+			},
+			expectedNextSyncAtMost: loadBalancerInventoryInterval - time.Minute,
+		},
+		{
+			name: "inventory failure keeps the last load balancers",
+			statusLoadBalancers: []albo.AWSLoadBalancerStatus{
+				{ARN: "lb-1", State: "active"},
+			},
+			sinceLastSync:  loadBalancerInventoryInterval,
+			describeErr:    errors.New("access denied"),
+			expectedSynced: true,
+			expectedLoadBalancers: []albo.AWSLoadBalancerStatus{
+				{ARN: "lb-1", State: "active"},
+			},
+			expectedConditionStatus: metav1.ConditionFalse,
+			expectedNextSyncAtMost:  loadBalancerInventoryRetryInterval,
+		},
+		{
+			name:           "failed inventory retried after the retry interval",
+			sinceLastSync:  loadBalancerInventoryRetryInterval,
+			lastSyncFailed: true,
+			loadBalancers: []elbv2types.LoadBalancer{
+				testActiveLoadBalancer("lb-1", "test-vpc", elbv2types.LoadBalancerSchemeEnumInternal, elbv2types.LoadBalancerTypeEnumApplication),
+			},
+			clusterTags: map[string]string{
+				"lb-1": "test-cluster",
+			},
+			expectedSynced: true,
+			expectedLoadBalancers: []albo.AWSLoadBalancerStatus{
+				{
+					ARN:     "lb-1",
+					DNSName: "lb-1.elb.amazonaws.com",
+					Scheme:  "internal",
+					Type:    "application",
+					State:   "active",
+				},
+			},
+			expectedConditionStatus: metav1.ConditionTrue,
+			expectedNextSyncAtMost:  loadBalancerInventoryInterval,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := &albo.AWSLoadBalancerController{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Status: albo.AWSLoadBalancerControllerStatus{
+					LoadBalancers: tc.statusLoadBalancers,
+				},
+			}
+			client := fake.NewClientBuilder().WithScheme(test.Scheme).WithObjects(controller).WithStatusSubresource(controller).Build()
+			if err := client.Get(context.Background(), types.NamespacedName{Name: controller.Name}, controller); err != nil {
+				t.Fatalf("failed to get the controller: %v", err)
+			}
+			r := &AWSLoadBalancerControllerReconciler{
+				Client: client,
+				ELBv2Client: &testELBv2Client{
+					t:             t,
+					loadBalancers: tc.loadBalancers,
+					clusterTags:   tc.clusterTags,
+					tags:          tc.tags,
+					describeErr:   tc.describeErr,
+				},
+				ClusterName: "test-cluster",
+				VPCID:       "test-vpc",
+			}
+			if tc.sinceLastSync != 0 {
+				r.loadBalancersSyncedAt = time.Now().Add(-tc.sinceLastSync)
+			}
+			r.loadBalancersSyncFailed = tc.lastSyncFailed
+
+			synced, nextSync, err := r.syncLoadBalancers(context.Background(), controller)
+			if err != nil {
+				t.Fatalf("got unexpected error: %v", err)
+			}
+			if synced != tc.expectedSynced {
+				t.Errorf("expected synced to be %t, got %t", tc.expectedSynced, synced)
+			}
+			if nextSync <= 0 || nextSync > tc.expectedNextSyncAtMost {
+				t.Errorf("expected next sync in at most %v, got %v", tc.expectedNextSyncAtMost, nextSync)
+			}
+
+			var updated albo.AWSLoadBalancerController
+			if err := client.Get(context.Background(), types.NamespacedName{Name: controller.Name}, &updated); err != nil {
+				t.Fatalf("failed to get the controller: %v", err)
+			}
+			if diff := cmp.Diff(tc.expectedLoadBalancers, updated.Status.LoadBalancers); diff != "" {
+				t.Errorf("unexpected load balancers status (-want +got):\n%s", diff)
+			}
+			var conditionStatus metav1.ConditionStatus
+			if condition := meta.FindStatusCondition(updated.Status.Conditions, LoadBalancersInventoriedCondition); condition != nil {
+				conditionStatus = condition.Status
+			}
+			if conditionStatus != tc.expectedConditionStatus {
+				t.Errorf("expected %s condition status %q, got %q", LoadBalancersInventoriedCondition, tc.expectedConditionStatus, conditionStatus)
+			}
+		})
+	}
+}
+
+func testActiveLoadBalancer(arn, vpcID string, scheme elbv2types.LoadBalancerSchemeEnum, lbType elbv2types.LoadBalancerTypeEnum) elbv2types.LoadBalancer {
+	lb := testLoadBalancer(arn, vpcID)
+	lb.DNSName = awstypes.String(arn + ".elb.amazonaws.com")
+	lb.Scheme = scheme
+	lb.Type = lbType
+	lb.State = &elbv2types.LoadBalancerState{Code: elbv2types.LoadBalancerStateEnumActive}
+	return lb
+}
@@ -0,0 +1,63 @@
+package awsloadbalancercontroller
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+var (
+	// minOperandVersion is the oldest version of the controller supported by the operator.
+	// The operand of an unknown version is assumed to be of this version.
+	minOperandVersion = version.MustParseSemantic("v2.2.0")
+	// admissionReviewV1MinOperandVersion is the first version of the controller
+	// which serves the admission.k8s.io/v1 reviews.
+	admissionReviewV1MinOperandVersion = version.MustParseSemantic("v2.4.0")
+)
+
+// ParseOperandVersion returns the version of the controller from the given version string
+// or from the tag of the given image if the version string is empty.
+// Nil is returned if the version cannot be inferred from the image (e.g. "latest" tag or digest reference),
+// the operand is then assumed to be the oldest supported one.
+func ParseOperandVersion(image, operandVersion string) (*version.Version, error) {
+	if operandVersion != "" {
+		v, err := version.ParseSemantic(operandVersion)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse operand version %q: %w", operandVersion, err)
+		}
+		return v, nil
+	}
+	if strings.Contains(image, "@") {
+		return nil, nil
+	}
+	// the registry host can have a port, only the last path element can have the tag
+	name := image[strings.LastIndex(image, "/")+1:]
+	idx := strings.LastIndex(name, ":")
+	if idx == -1 {
+		return nil, nil
+	}
+	if v, err := version.ParseSemantic(name[idx+1:]); err == nil {
+		return v, nil
+	}
+	return nil, nil
+}
+
+// operandAtLeast returns true if the operand version is greater or equal to the given version.
+// An unknown operand version is considered to be the oldest supported one so that the operand
+// doesn't get the settings it may not know.
+func (r *AWSLoadBalancerControllerReconciler) operandAtLeast(minVersion *version.Version) bool {
+	if r.OperandVersion == nil {
+		return minOperandVersion.AtLeast(minVersion)
+	}
+	return r.OperandVersion.AtLeast(minVersion)
+}
+
+// desiredAdmissionReviewVersions returns the admission review versions which the webhooks
+// of the controller accept in the order of preference.
+func (r *AWSLoadBalancerControllerReconciler) desiredAdmissionReviewVersions() []string {
+	if r.operandAtLeast(admissionReviewV1MinOperandVersion) {
+		return []string{"v1", "v1beta1"}
+	}
+	return []string{"v1beta1"}
+}
@@ -0,0 +1,80 @@
+package awsloadbalancercontroller
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+func TestParseOperandVersion(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		image           string
+		operandVersion  string
+		expectedVersion *version.Version
+		expectError     bool
+	}{
+		{
+			name:            "version from tag",
+			image:           "quay.io/aws-load-balancer-operator/aws-load-balancer-controller:v2.8.2",
+			expectedVersion: version.MustParseSemantic("v2.8.2"),
+		},
+		{
+			name:            "version from tag without prefix",
+			image:           "registry.example.com:5000/aws-load-balancer-controller:2.3.1",
+			expectedVersion: version.MustParseSemantic("v2.3.1"),
+		},
+		{
+			name:  "latest tag",
+			image: "quay.io/aws-load-balancer-operator/aws-load-balancer-controller:latest",
+		},
+		{
+			name:  "no tag",
+			image: "registry.example.com:5000/aws-load-balancer-controller",
+		},
+		{
+			name:  "digest",
+			image: "quay.io/aws-load-balancer-operator/aws-load-balancer-controller@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		},
+		{
+			name:            "explicit version overrides the tag",
+			image:           "quay.io/aws-load-balancer-operator/aws-load-balancer-controller:v2.8.2",
+			operandVersion:  "v2.3.0",
+			expectedVersion: version.MustParseSemantic("v2.3.0"),
+		},
+		{
+			name:            "explicit version with digest",
+			image:           "quay.io/aws-load-balancer-operator/aws-load-balancer-controller@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+			operandVersion:  "2.7.1",
+			expectedVersion: version.MustParseSemantic("v2.7.1"),
+		},
+		{
+			name:           "invalid explicit version",
+			image:          "quay.io/aws-load-balancer-operator/aws-load-balancer-controller:latest",
+			operandVersion: "2.x",
+			expectError:    true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := ParseOperandVersion(tc.image, tc.operandVersion)
+			if tc.expectError {
+				if err == nil {
+					t.Fatalf("expected error, got version %v", v)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.expectedVersion == nil {
+				if v != nil {
+					t.Fatalf("expected unknown version, got %v", v)
+				}
+				return
+			}
+			if v == nil || v.String() != tc.expectedVersion.String() {
+				t.Fatalf("expected version %v, got %v", tc.expectedVersion, v)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package awsloadbalancercontroller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	albo "github.com/openshift/aws-load-balancer-operator/api/v1"
+	"github.com/openshift/aws-load-balancer-operator/pkg/aws"
+)
+
+const (
+	// permissionsCheckInterval is the interval between two checks of the same credentials
+	// to detect the changes of the IAM policies attached to the principal.
+	permissionsCheckInterval = time.Hour
+	// permissionsCheckRetryInterval is the interval between a failed check and the next one.
+	permissionsCheckRetryInterval = time.Minute * 5
+	// maxReportedMissingActions is the maximum number of missing actions listed in the condition message.
+	maxReportedMissingActions = 20
+)
+
+// checkCredentialsPermissions simulates the controller IAM policy against the principal of the given credentials secret
+// and reports the denied actions in the CredentialsPermissionsValid condition. The same credentials are checked
+// at most once per interval, the duration until the next check is returned. The failures of the check
+// are reported in the condition and don't fail the reconciliation, the failed check is retried after a shorter interval.
+func (r *AWSLoadBalancerControllerReconciler) checkCredentialsPermissions(ctx context.Context, controller *albo.AWSLoadBalancerController, secret *corev1.Secret) (time.Duration, error) {
+	if controller.Spec.CredentialsPermissionsCheck == albo.DisabledCredentialsPermissionsCheckPolicy || r.IAMClient == nil {
+		// check again as soon as the check is enabled
+		r.permissionsCheckedAt, r.permissionsCheckedHash, r.permissionsCheckFailed = time.Time{}, "", false
+		return permissionsCheckInterval, r.updateStatusConditions(ctx, controller, credentialsPermissionsConditions("", nil, nil, false, controller.Generation)...)
+	}
+
+	secretHash, err := buildSecretHash(secret.Data)
+	if err != nil {
+		return 0, fmt.Errorf("failed to build hash of credentials secret %q: %w", secret.Name, err)
+	}
+	if sinceLastCheck, interval := time.Since(r.permissionsCheckedAt), r.nextPermissionsCheckInterval(); secretHash == r.permissionsCheckedHash && sinceLastCheck < interval {
+		return interval - sinceLastCheck, nil
+	}
+
+	principalARN, missingActions, checkErr := r.getMissingActions(ctx, secret)
+	if checkErr != nil {
+		log.FromContext(ctx).Info("failed to check the permissions of the controller credentials", "secret", secret.Name, "reason", checkErr)
+	}
+	r.permissionsCheckedAt, r.permissionsCheckedHash, r.permissionsCheckFailed = time.Now(), secretHash, checkErr != nil
+	if err := r.updateStatusConditions(ctx, controller, credentialsPermissionsConditions(principalARN, missingActions, checkErr, true, controller.Generation)...); err != nil {
+		return 0, err
+	}
+	return r.nextPermissionsCheckInterval(), nil
+}
+
+// nextPermissionsCheckInterval returns the interval between the last check of the controller credentials permissions and the next one.
+func (r *AWSLoadBalancerControllerReconciler) nextPermissionsCheckInterval() time.Duration {
+	if r.permissionsCheckFailed {
+		return permissionsCheckRetryInterval
+	}
+	return permissionsCheckInterval
+}
+
+// getMissingActions returns the principal of the given credentials secret
+// along with the actions of the controller IAM policy which the principal is not allowed to perform.
+func (r *AWSLoadBalancerControllerReconciler) getMissingActions(ctx context.Context, secret *corev1.Secret) (string, []string, error) {
+	principalARN, err := r.getCredentialsPrincipal(ctx, secret)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var missingActions []string
+	for _, statement := range GetIAMPolicy().Statement {
+		var resources []string
+		if statement.Resource != "*" {
+			resources = []string{statement.Resource}
+		}
+		denied, err := aws.GetDeniedActions(ctx, r.IAMClient, principalARN, statement.Action, resources)
+		if err != nil {
+			return principalARN, nil, fmt.Errorf("failed to simulate the policies of %q: %w", principalARN, err)
+		}
+		missingActions = append(missingActions, denied...)
+	}
+	return principalARN, sets.List(sets.New(missingActions...)), nil
+}
+
+// getCredentialsPrincipal returns the ARN of the IAM role or user of the default profile of the given credentials secret.
+// The role is taken from the profile while the user or the role of the static credentials is asked to STS and IAM.
+func (r *AWSLoadBalancerControllerReconciler) getCredentialsPrincipal(ctx context.Context, secret *corev1.Secret) (string, error) {
+	profiles, err := parseSharedCredentials(secret.Data[credentialsSecretKey])
+	if err != nil {
+		return "", fmt.Errorf("failed to parse credentials of secret %q: %w", secret.Name, err)
+	}
+	profile, found := profiles[defaultCredentialsProfile]
+	if !found {
+		return "", fmt.Errorf("credentials secret %q does not have the %q profile", secret.Name, defaultCredentialsProfile)
+	}
+	if roleARN, found := profile[roleARNKey]; found {
+		return roleARN, nil
+	}
+	if r.NewSTSClient == nil {
+		return "", fmt.Errorf("no STS client to get the identity of the static credentials")
+	}
+	stsClient, err := r.NewSTSClient(ctx, r.AWSRegion, profile[accessKeyIDKey], profile[secretAccessKeyKey])
+	if err != nil {
+		return "", fmt.Errorf("failed to make aws sts client: %w", err)
+	}
+	return aws.GetPrincipalARN(ctx, stsClient, r.IAMClient)
+}
+
+// credentialsPermissionsConditions returns the condition reporting whether the controller credentials
+// are allowed to perform all the actions of the controller IAM policy.
+func credentialsPermissionsConditions(principalARN string, missingActions []string, checkErr error, enabled bool, generation int64) []metav1.Condition {
+	condition := metav1.Condition{
+		Type:               CredentialsPermissionsValidCondition,
+		ObservedGeneration: generation,
+	}
+	switch {
+	case !enabled:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = "PermissionsCheckDisabled"
+		condition.Message = "The permissions of the controller credentials are not checked"
+	case checkErr != nil:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = "PermissionsCheckFailed"
+		condition.Message = fmt.Sprintf("Failed to check the permissions of the controller credentials: %v", checkErr)
+	case len(missingActions) > 0:
+		reported := missingActions
+		if len(reported) > maxReportedMissingActions {
+			reported = append(reported[:maxReportedMissingActions:maxReportedMissingActions], fmt.Sprintf("and %d more", len(missingActions)-maxReportedMissingActions))
+		}
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "MissingPermissions"
+		condition.Message = fmt.Sprintf("%s is not allowed to perform %d actions required by the controller: %s", principalARN, len(missingActions), strings.Join(reported, ", "))
+	default:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "AllPermissionsGranted"
+		condition.Message = fmt.Sprintf("%s is allowed to perform all the actions required by the controller", principalARN)
+	}
+	return []metav1.Condition{condition}
+}
@@ -0,0 +1,272 @@
+package awsloadbalancercontroller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	awstypes "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	albo "github.com/openshift/aws-load-balancer-operator/api/v1"
+	"github.com/openshift/aws-load-balancer-operator/pkg/aws"
+	"github.com/openshift/aws-load-balancer-operator/pkg/utils/test"
+)
+
+// testIAMClient denies the given actions to all the principals.
+type testIAMClient struct {
+	denied map[string]bool
+	err    error
+	// principals are the principals whose policies were simulated
+	principals map[string]bool
+	// calls is the number of simulations
+	calls int
+}
+
+func (c *testIAMClient) SimulatePrincipalPolicy(_ context.Context, input *iam.SimulatePrincipalPolicyInput, _ ...func(*iam.Options)) (*iam.SimulatePrincipalPolicyOutput, error) {
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	c.principals[awstypes.ToString(input.PolicySourceArn)] = true
+	output := &iam.SimulatePrincipalPolicyOutput{}
+	for _, action := range input.ActionNames {
+		decision := iamtypes.PolicyEvaluationDecisionTypeAllowed
+		if c.denied[action] {
+			decision = iamtypes.PolicyEvaluationDecisionTypeImplicitDeny
+		}
+		output.EvaluationResults = append(output.EvaluationResults, iamtypes.EvaluationResult{EvalActionName: awstypes.String(action), EvalDecision: decision})
+	}
+	return output, nil
+}
+
+func (c *testIAMClient) GetRole(_ context.Context, input *iam.GetRoleInput, _ ...func(*iam.Options)) (*iam.GetRoleOutput, error) {
+	return &iam.GetRoleOutput{Role: &iamtypes.Role{Arn: awstypes.String("arn:aws:iam::123456789012:role/" + awstypes.ToString(input.RoleName))}}, nil
+}
+
+type testSTSClient struct {
+	arn string
+}
+
+func (c *testSTSClient) GetCallerIdentity(_ context.Context, _ *sts.GetCallerIdentityInput, _ ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error) {
+	return &sts.GetCallerIdentityOutput{Arn: awstypes.String(c.arn)}, nil
+}
+
+func TestCheckCredentialsPermissions(t *testing.T) {
+	staticCredentials := "[default]\naws_access_key_id = AKIAEXAMPLE\naws_secret_access_key = secret\n"
+	stsCredentials := "[default]\nrole_arn = arn:aws:iam::123456789012:role/test-role\nweb_identity_token_file = /var/run/secrets/openshift/serviceaccount/token\n"
+
+	for _, tc := range []struct {
+		name               string
+		checkPolicy        albo.CredentialsPermissionsCheckPolicy
+		credentials        string
+		deniedActions      []string
+		iamErr             error
+		sinceLastCheck     time.Duration
+		checkedHashMatches bool
+		lastCheckFailed    bool
+		expectedPrincipals []string
+		expectedCondition  *metav1.Condition
+	}{
+		{
+			name:               "all permissions granted to the role",
+			credentials:        stsCredentials,
+			expectedPrincipals: []string{"arn:aws:iam::123456789012:role/test-role"},
+			expectedCondition: &metav1.Condition{
+				Type:    CredentialsPermissionsValidCondition,
+				Status:  metav1.ConditionTrue,
+				Reason:  "AllPermissionsGranted",
+				Message: "arn:aws:iam::123456789012:role/test-role is allowed to perform all the actions required by the controller",
+			},
+		},
+		{
+			name:               "permissions missing for the user of the static credentials",
+			credentials:        staticCredentials,
+			deniedActions:      []string{"elasticloadbalancing:AddTags", "ec2:DescribeVpcs"},
+			expectedPrincipals: []string{"arn:aws:iam::123456789012:user/test-user"},
+			expectedCondition: &metav1.Condition{
+				Type:    CredentialsPermissionsValidCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  "MissingPermissions",
+				Message: "arn:aws:iam::123456789012:user/test-user is not allowed to perform 2 actions required by the controller: ec2:DescribeVpcs, elasticloadbalancing:AddTags",
+			},
+		},
+		{
+			name:        "simulation failure",
+			credentials: stsCredentials,
+			iamErr:      fmt.Errorf("AccessDenied"),
+			expectedCondition: &metav1.Condition{
+				Type:    CredentialsPermissionsValidCondition,
+				Status:  metav1.ConditionUnknown,
+				Reason:  "PermissionsCheckFailed",
+				Message: `Failed to check the permissions of the controller credentials: failed to simulate the policies of "arn:aws:iam::123456789012:role/test-role": AccessDenied`,
+			},
+		},
+		{
+			name:        "check disabled",
+			checkPolicy: albo.DisabledCredentialsPermissionsCheckPolicy,
+			credentials: stsCredentials,
+			expectedCondition: &metav1.Condition{
+				Type:    CredentialsPermissionsValidCondition,
+				Status:  metav1.ConditionUnknown,
+				Reason:  "PermissionsCheckDisabled",
+				Message: "The permissions of the controller credentials are not checked",
+			},
+		},
+		{
+			name:               "same credentials checked recently",
+			credentials:        stsCredentials,
+			sinceLastCheck:     time.Minute,
+			checkedHashMatches: true,
+		},
+		{
+			name:               "failed check not repeated before the retry interval",
+			credentials:        stsCredentials,
+			iamErr:             fmt.Errorf("Throttling"),
+			sinceLastCheck:     time.Minute,
+			checkedHashMatches: true,
+			lastCheckFailed:    true,
+		},
+		{
+			name:               "failed check retried after the retry interval",
+			credentials:        stsCredentials,
+			sinceLastCheck:     permissionsCheckRetryInterval,
+			checkedHashMatches: true,
+			lastCheckFailed:    true,
+			expectedPrincipals: []string{"arn:aws:iam::123456789012:role/test-role"},
+			expectedCondition: &metav1.Condition{
+				Type:    CredentialsPermissionsValidCondition,
+				Status:  metav1.ConditionTrue,
+				Reason:  "AllPermissionsGranted",
+				Message: "arn:aws:iam::123456789012:role/test-role is allowed to perform all the actions required by the controller",
+			},
+		},
+		{
+			name:               "rotated credentials checked again",
+			credentials:        staticCredentials,
+			sinceLastCheck:     time.Minute,
+			expectedPrincipals: []string{"arn:aws:iam::123456789012:user/test-user"},
+			expectedCondition: &metav1.Condition{
+				Type:    CredentialsPermissionsValidCondition,
+				Status:  metav1.ConditionTrue,
+				Reason:  "AllPermissionsGranted",
+				Message: "arn:aws:iam::123456789012:user/test-user is allowed to perform all the actions required by the controller",
+			},
+		},
+		{
+			name:               "same credentials checked again after the interval",
+			credentials:        stsCredentials,
+			sinceLastCheck:     permissionsCheckInterval,
+			checkedHashMatches: true,
+			expectedPrincipals: []string{"arn:aws:iam::123456789012:role/test-role"},
+			expectedCondition: &metav1.Condition{
+				Type:    CredentialsPermissionsValidCondition,
+				Status:  metav1.ConditionTrue,
+				Reason:  "AllPermissionsGranted",
+				Message: "arn:aws:iam::123456789012:role/test-role is allowed to perform all the actions required by the controller",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := &albo.AWSLoadBalancerController{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Spec: albo.AWSLoadBalancerControllerSpec{
+					CredentialsPermissionsCheck: tc.checkPolicy,
+				},
+			}
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "aws-load-balancer-controller-cluster", Namespace: "aws-load-balancer-operator"},
+				Data:       map[string][]byte{"credentials": []byte(tc.credentials)},
+			}
+			client := fake.NewClientBuilder().WithScheme(test.Scheme).WithObjects(controller).WithStatusSubresource(controller).Build()
+			if err := client.Get(context.Background(), types.NamespacedName{Name: controller.Name}, controller); err != nil {
+				t.Fatalf("failed to get the controller: %v", err)
+			}
+
+			denied := map[string]bool{}
+			for _, action := range tc.deniedActions {
+				denied[action] = true
+			}
+			iamClient := &testIAMClient{denied: denied, err: tc.iamErr, principals: map[string]bool{}}
+			r := &AWSLoadBalancerControllerReconciler{
+				Client:    client,
+				IAMClient: iamClient,
+				NewSTSClient: func(_ context.Context, _, _, _ string) (aws.STSClient, error) {
+					return &testSTSClient{arn: "arn:aws:iam::123456789012:user/test-user"}, nil
+				},
+			}
+			if tc.sinceLastCheck != 0 {
+				r.permissionsCheckedAt = time.Now().Add(-tc.sinceLastCheck)
+				r.permissionsCheckedHash = "previous"
+				r.permissionsCheckFailed = tc.lastCheckFailed
+				if tc.checkedHashMatches {
+					hash, err := buildSecretHash(secret.Data)
+					if err != nil {
+						t.Fatalf("failed to build secret hash: %v", err)
+					}
+					r.permissionsCheckedHash = hash
+				}
+			}
+
+			nextCheck, err := r.checkCredentialsPermissions(context.Background(), controller, secret)
+			if err != nil {
+				t.Fatalf("got unexpected error: %v", err)
+			}
+			maxNextCheck := permissionsCheckInterval
+			if tc.iamErr != nil {
+				maxNextCheck = permissionsCheckRetryInterval
+			}
+			if nextCheck <= 0 || nextCheck > maxNextCheck {
+				t.Errorf("expected next check in at most %v, got %v", maxNextCheck, nextCheck)
+			}
+			if tc.expectedCondition == nil && iamClient.calls != 0 {
+				t.Errorf("expected no policy simulation, got %d", iamClient.calls)
+			}
+
+			var principals []string
+			for principal := range iamClient.principals {
+				principals = append(principals, principal)
+			}
+			if diff := cmp.Diff(tc.expectedPrincipals, principals); diff != "" {
+				t.Errorf("unexpected simulated principals (-want +got):\n%s", diff)
+			}
+
+			var updated albo.AWSLoadBalancerController
+			if err := client.Get(context.Background(), types.NamespacedName{Name: controller.Name}, &updated); err != nil {
+				t.Fatalf("failed to get the controller: %v", err)
+			}
+			var expectedConditions []metav1.Condition
+			if tc.expectedCondition != nil {
+				expectedConditions = append(expectedConditions, *tc.expectedCondition)
+			}
+			if diff := cmp.Diff(expectedConditions, updated.Status.Conditions, cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("unexpected conditions (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCredentialsPermissionsConditionsTruncatesMissingActions(t *testing.T) {
+	var missingActions []string
+	for i := 0; i < maxReportedMissingActions+3; i++ {
+		missingActions = append(missingActions, fmt.Sprintf("ec2:Action%02d", i))
+	}
+	conditions := credentialsPermissionsConditions("arn:aws:iam::123456789012:role/test-role", missingActions, nil, true, 1)
+	expected := fmt.Sprintf("arn:aws:iam::123456789012:role/test-role is not allowed to perform %d actions required by the controller: ", len(missingActions))
+	for _, action := range missingActions[:maxReportedMissingActions] {
+		expected += action + ", "
+	}
+	expected += "and 3 more"
+	if conditions[0].Message != expected {
+		t.Errorf("unexpected message, expected %q, got %q", expected, conditions[0].Message)
+	}
+}
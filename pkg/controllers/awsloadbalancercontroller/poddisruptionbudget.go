@@ -0,0 +1,123 @@
+package awsloadbalancercontroller
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	albo "github.com/openshift/aws-load-balancer-operator/api/v1"
+)
+
+const (
+	// zoneTopologyKey is the node label with the availability zone of the node.
+	zoneTopologyKey = "topology.kubernetes.io/zone"
+	// hostTopologyKey is the node label with the hostname of the node.
+	hostTopologyKey = "kubernetes.io/hostname"
+)
+
+// ensurePodDisruptionBudget ensures the controller pods are protected by a PodDisruptionBudget
+// when the controller has more than 1 replica. Otherwise a node drain could evict all the replicas at once
+// leaving the controller webhooks without a backend. The budget is removed if the controller is scaled down to 1 replica
+// as it would block the node drains.
+func (r *AWSLoadBalancerControllerReconciler) ensurePodDisruptionBudget(ctx context.Context, namespace string, controller *albo.AWSLoadBalancerController, deployment *appsv1.Deployment) error {
+	pdbName := types.NamespacedName{
+		Name:      fmt.Sprintf("%s-%s", controllerResourcePrefix, controller.Name),
+		Namespace: namespace,
+	}
+
+	var current policyv1.PodDisruptionBudget
+	exists := true
+	if err := r.Get(ctx, pdbName, &current); err != nil {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to get existing pod disruption budget %q: %w", pdbName, err)
+		}
+		exists = false
+	}
+
+	replicas := controllerReplicas(controller)
+	if replicas <= 1 {
+		if exists {
+			if err := r.Delete(ctx, &current); err != nil && !errors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete pod disruption budget %q: %w", pdbName, err)
+			}
+		}
+		return nil
+	}
+
+	desired := desiredPodDisruptionBudget(pdbName.Name, pdbName.Namespace, replicas, deployment.Spec.Selector)
+	if err := controllerutil.SetControllerReference(controller, desired, r.Scheme); err != nil {
+		return fmt.Errorf("failed to set owner reference on desired pod disruption budget: %w", err)
+	}
+	if !exists {
+		return r.Create(ctx, desired)
+	}
+	return r.updatePodDisruptionBudget(ctx, &current, desired)
+}
+
+// desiredPodDisruptionBudget returns a PodDisruptionBudget which allows
+// only 1 replica of the controller to be unavailable at a time.
+func desiredPodDisruptionBudget(name, namespace string, replicas int32, selector *metav1.LabelSelector) *policyv1.PodDisruptionBudget {
+	minAvailable := intstr.FromInt32(replicas - 1)
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable: &minAvailable,
+			Selector:     selector,
+		},
+	}
+}
+
+func (r *AWSLoadBalancerControllerReconciler) updatePodDisruptionBudget(ctx context.Context, current, desired *policyv1.PodDisruptionBudget) error {
+	if equality.Semantic.DeepEqual(current.Spec.MinAvailable, desired.Spec.MinAvailable) &&
+		current.Spec.MaxUnavailable == nil &&
+		equality.Semantic.DeepEqual(current.Spec.Selector, desired.Spec.Selector) {
+		return nil
+	}
+	updated := current.DeepCopy()
+	updated.Spec.MinAvailable = desired.Spec.MinAvailable
+	updated.Spec.MaxUnavailable = nil
+	updated.Spec.Selector = desired.Spec.Selector
+	return r.Update(ctx, updated)
+}
+
+// desiredTopologySpreadConstraints returns the constraints which spread the controller replicas
+// across the zones and the nodes. The constraints are soft to let the replicas be scheduled
+// when the cluster has fewer zones or nodes than replicas.
+func desiredTopologySpreadConstraints(replicas int32, podLabels map[string]string) []corev1.TopologySpreadConstraint {
+	if replicas <= 1 {
+		return nil
+	}
+	var constraints []corev1.TopologySpreadConstraint
+	for _, topologyKey := range []string{zoneTopologyKey, hostTopologyKey} {
+		constraints = append(constraints, corev1.TopologySpreadConstraint{
+			MaxSkew:           1,
+			TopologyKey:       topologyKey,
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: podLabels,
+			},
+		})
+	}
+	return constraints
+}
+
+// controllerReplicas returns the desired number of the controller replicas.
+func controllerReplicas(controller *albo.AWSLoadBalancerController) int32 {
+	if controller.Spec.Config == nil || controller.Spec.Config.Replicas == 0 {
+		return 1
+	}
+	return controller.Spec.Config.Replicas
+}
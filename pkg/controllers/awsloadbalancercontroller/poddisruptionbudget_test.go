@@ -0,0 +1,124 @@
+package awsloadbalancercontroller
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	"github.com/google/go-cmp/cmp"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	albo "github.com/openshift/aws-load-balancer-operator/api/v1"
+	"github.com/openshift/aws-load-balancer-operator/pkg/utils/test"
+)
+
+func testPodDisruptionBudget(name, namespace string, minAvailable *intstr.IntOrString, maxUnavailable *intstr.IntOrString) *policyv1.PodDisruptionBudget {
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable:   minAvailable,
+			MaxUnavailable: maxUnavailable,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "controller"},
+			},
+		},
+	}
+}
+
+func TestEnsurePodDisruptionBudget(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		existingObjects []client.Object
+		replicas        int32
+		expectedPDB     *policyv1.PodDisruptionBudget
+	}{
+		{
+			name:     "single replica",
+			replicas: 1,
+		},
+		{
+			name: "replicas not set",
+		},
+		{
+			name:        "new pod disruption budget",
+			replicas:    2,
+			expectedPDB: testPodDisruptionBudget("aws-load-balancer-controller-cluster", "test-namespace", ptr.To(intstr.FromInt32(1)), nil),
+		},
+		{
+			name:     "replicas increased",
+			replicas: 3,
+			existingObjects: []client.Object{
+				testPodDisruptionBudget("aws-load-balancer-controller-cluster", "test-namespace", ptr.To(intstr.FromInt32(1)), nil),
+			},
+			expectedPDB: testPodDisruptionBudget("aws-load-balancer-controller-cluster", "test-namespace", ptr.To(intstr.FromInt32(2)), nil),
+		},
+		{
+			name:     "modified pod disruption budget",
+			replicas: 2,
+			existingObjects: []client.Object{
+				testPodDisruptionBudget("aws-load-balancer-controller-cluster", "test-namespace", nil, ptr.To(intstr.FromString("100%"))),
+			},
+			expectedPDB: testPodDisruptionBudget("aws-load-balancer-controller-cluster", "test-namespace", ptr.To(intstr.FromInt32(1)), nil),
+		},
+		{
+			name:     "scaled down to single replica",
+			replicas: 1,
+			existingObjects: []client.Object{
+				testPodDisruptionBudget("aws-load-balancer-controller-cluster", "test-namespace", ptr.To(intstr.FromInt32(1)), nil),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			testClient := fake.NewClientBuilder().WithObjects(tc.existingObjects...).WithScheme(test.Scheme).Build()
+			r := &AWSLoadBalancerControllerReconciler{
+				Client: testClient,
+				Scheme: test.Scheme,
+			}
+			controller := &albo.AWSLoadBalancerController{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Spec: albo.AWSLoadBalancerControllerSpec{
+					Config: &albo.AWSLoadBalancerDeploymentConfig{Replicas: tc.replicas},
+				},
+			}
+			deployment := &appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "controller"},
+					},
+				},
+			}
+			if err := r.ensurePodDisruptionBudget(context.Background(), "test-namespace", controller, deployment); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var pdb policyv1.PodDisruptionBudget
+			err := testClient.Get(context.Background(), types.NamespacedName{Name: "aws-load-balancer-controller-cluster", Namespace: "test-namespace"}, &pdb)
+			if tc.expectedPDB == nil {
+				if !errors.IsNotFound(err) {
+					t.Errorf("expected no pod disruption budget, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to get pod disruption budget: %v", err)
+			}
+			if diff := cmp.Diff(tc.expectedPDB.Spec, pdb.Spec); diff != "" {
+				t.Errorf("unexpected pod disruption budget spec (-want +got):\n%s", diff)
+			}
+			if len(tc.existingObjects) == 0 && (len(pdb.OwnerReferences) != 1 || pdb.OwnerReferences[0].Name != controller.Name) {
+				t.Errorf("expected pod disruption budget to be owned by %s, got %v", controller.Name, pdb.OwnerReferences)
+			}
+		})
+	}
+}
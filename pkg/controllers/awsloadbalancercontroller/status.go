@@ -2,10 +2,14 @@ package awsloadbalancercontroller
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -15,15 +19,26 @@ import (
 )
 
 const (
-	DeploymentAvailableCondition        = "DeploymentAvailable"
-	DeploymentUpgradingCondition        = "DeploymentUpgrading"
-	CredentialsSecretAvailableCondition = "CredentialsSecretAvailable"
+	DeploymentAvailableCondition         = "DeploymentAvailable"
+	DeploymentUpgradingCondition         = "DeploymentUpgrading"
+	CredentialsSecretAvailableCondition  = "CredentialsSecretAvailable"
+	SubnetsReadyCondition                = "SubnetsReady"
+	SubnetsDegradedCondition             = "SubnetsDegraded"
+	DeletingCondition                    = "Deleting"
+	FeatureGatesValidCondition           = "FeatureGatesValid"
+	IngressClassAvailableCondition       = "IngressClassAvailable"
+	DefaultIngressClassConflictCondition = "DefaultIngressClassConflict"
+	CredentialsPermissionsValidCondition = "CredentialsPermissionsValid"
+	LoadBalancersInventoriedCondition    = "LoadBalancersInventoried"
+
+	// minLoadBalancerAvailabilityZones is the number of availability zones required by an application load balancer.
+	minLoadBalancerAvailabilityZones = 2
 )
 
-func (r *AWSLoadBalancerControllerReconciler) updateControllerStatus(ctx context.Context, controller *albo.AWSLoadBalancerController, deployment *appsv1.Deployment, secretName string, secretProvisioned bool) error {
+func (r *AWSLoadBalancerControllerReconciler) updateControllerStatus(ctx context.Context, controller *albo.AWSLoadBalancerController, deployment *appsv1.Deployment, secretName string, secretProvisioned bool, secretErr error) error {
 	status := controller.Status.DeepCopy()
 
-	status.Conditions = mergeConditions(status.Conditions, credentialsSecretConditions(secretName, secretProvisioned, controller.Generation)...)
+	status.Conditions = mergeConditions(status.Conditions, credentialsSecretConditions(secretName, secretProvisioned, secretErr, controller.Generation)...)
 
 	if deployment != nil {
 		status.Conditions = mergeConditions(status.Conditions, deploymentConditions(deployment, controller.Generation)...)
@@ -36,9 +51,20 @@ func (r *AWSLoadBalancerControllerReconciler) updateControllerStatus(ctx context
 	return nil
 }
 
-func credentialsSecretConditions(secretName string, secretProvisioned bool, generation int64) []metav1.Condition {
+// credentialsSecretConditions returns the condition reporting whether the credentials secret is provisioned and valid.
+// The given secret error is expected to be a credentialsSecretError whose reason is reported.
+func credentialsSecretConditions(secretName string, secretProvisioned bool, secretErr error, generation int64) []metav1.Condition {
 	var conditions []metav1.Condition
-	if secretProvisioned {
+	var credsErr *credentialsSecretError
+	if secretProvisioned && errors.As(secretErr, &credsErr) {
+		conditions = append(conditions, metav1.Condition{
+			Type:               CredentialsSecretAvailableCondition,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: generation,
+			Reason:             credsErr.reason,
+			Message:            credsErr.message,
+		})
+	} else if secretProvisioned {
 		conditions = append(conditions, metav1.Condition{
 			Type:               CredentialsSecretAvailableCondition,
 			Status:             metav1.ConditionTrue,
@@ -58,6 +84,89 @@ func credentialsSecretConditions(secretName string, secretProvisioned bool, gene
 	return conditions
 }
 
+// subnetConditions returns the conditions describing the ability of the cluster subnets to host load balancers.
+// The subnets are degraded if the public or internal subnets span less than two availability zones,
+// if some subnets have both role tags, if some selected subnets cannot get the role of their selector
+// or if some public or internal subnets have less available IP addresses than the given minimum.
+func subnetConditions(details []albo.AWSLoadBalancerControllerSubnetDetails, dualRoleSubnets, selectionConflicts []string, minAvailableIPs int32, generation int64) []metav1.Condition {
+	var reasons, messages []string
+	addProblem := func(reason, message string) {
+		reasons = append(reasons, reason)
+		messages = append(messages, message)
+	}
+
+	if len(dualRoleSubnets) > 0 {
+		addProblem("DualRoleSubnets", fmt.Sprintf("Subnets %s have both tags %s and %s", strings.Join(dualRoleSubnets, ", "), internalELBTagKey, publicELBTagKey))
+	}
+	if len(selectionConflicts) > 0 {
+		addProblem("SubnetSelectionConflict", fmt.Sprintf("Selected subnets are not tagged: %s", strings.Join(selectionConflicts, ", ")))
+	}
+
+	zones := map[albo.SubnetRole]sets.Set[string]{
+		albo.PublicSubnetRole:   sets.New[string](),
+		albo.InternalSubnetRole: sets.New[string](),
+	}
+	var lowIPSubnets []string
+	for _, detail := range details {
+		if detail.Role == "" {
+			continue
+		}
+		if detail.AvailabilityZone != "" {
+			zones[detail.Role].Insert(detail.AvailabilityZone)
+		}
+		if detail.AvailableIPAddressCount < minAvailableIPs {
+			lowIPSubnets = append(lowIPSubnets, fmt.Sprintf("%s (%d)", detail.ID, detail.AvailableIPAddressCount))
+		}
+	}
+	for _, role := range []albo.SubnetRole{albo.PublicSubnetRole, albo.InternalSubnetRole} {
+		switch {
+		case zones[role].Len() == 0:
+			addProblem("InsufficientAvailabilityZones", fmt.Sprintf("%s subnets span no availability zone, at least %d availability zones are required by application load balancers", role, minLoadBalancerAvailabilityZones))
+		case zones[role].Len() < minLoadBalancerAvailabilityZones:
+			addProblem("InsufficientAvailabilityZones", fmt.Sprintf("%s subnets span only availability zones %s, at least %d availability zones are required by application load balancers", role, strings.Join(sets.List(zones[role]), ", "), minLoadBalancerAvailabilityZones))
+		}
+	}
+	if len(lowIPSubnets) > 0 {
+		addProblem("LowAvailableIPAddresses", fmt.Sprintf("Subnets %s have less than %d available IP addresses", strings.Join(lowIPSubnets, ", "), minAvailableIPs))
+	}
+
+	if len(reasons) == 0 {
+		return []metav1.Condition{
+			{
+				Type:               SubnetsReadyCondition,
+				Status:             metav1.ConditionTrue,
+				ObservedGeneration: generation,
+				Reason:             "SubnetsHealthy",
+				Message:            "Subnets are ready to host load balancers",
+			},
+			{
+				Type:               SubnetsDegradedCondition,
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: generation,
+				Reason:             "SubnetsHealthy",
+				Message:            "Subnets are ready to host load balancers",
+			},
+		}
+	}
+	message := strings.Join(messages, "; ")
+	return []metav1.Condition{
+		{
+			Type:               SubnetsReadyCondition,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: generation,
+			Reason:             reasons[0],
+			Message:            message,
+		},
+		{
+			Type:               SubnetsDegradedCondition,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: generation,
+			Reason:             reasons[0],
+			Message:            message,
+		},
+	}
+}
+
 func deploymentConditions(deployment *appsv1.Deployment, generation int64) []metav1.Condition {
 	var conditions []metav1.Condition
 
@@ -142,10 +251,27 @@ func haveConditionsChanged(current []metav1.Condition, desired []metav1.Conditio
 	return !cmp.Equal(current, desired, opts)
 }
 
-func (r *AWSLoadBalancerControllerReconciler) updateStatusSubnets(ctx context.Context, controller *albo.AWSLoadBalancerController, internal []string, public []string, untagged []string, tagged []string, policy albo.SubnetTaggingPolicy) error {
+// updateStatusConditions merges the given conditions into the status of the controller.
+func (r *AWSLoadBalancerControllerReconciler) updateStatusConditions(ctx context.Context, controller *albo.AWSLoadBalancerController, conditions ...metav1.Condition) error {
+	status := controller.Status.DeepCopy()
+	status.Conditions = mergeConditions(status.Conditions, conditions...)
+	if haveConditionsChanged(controller.Status.Conditions, status.Conditions) {
+		controller.Status.Conditions = status.Conditions
+		return r.Status().Update(ctx, controller)
+	}
+	return nil
+}
+
+// updateStatusSubnets updates the subnets status along with the given subnet conditions.
+func (r *AWSLoadBalancerControllerReconciler) updateStatusSubnets(ctx context.Context, controller *albo.AWSLoadBalancerController, internal []string, public []string, untagged []string, tagged []string, conflicting []string, details []albo.AWSLoadBalancerControllerSubnetDetails, policy albo.SubnetTaggingPolicy, conditions ...metav1.Condition) error {
 	updatedALBC := controller.DeepCopy()
 	var updated bool
 
+	if len(conditions) > 0 {
+		updatedALBC.Status.Conditions = mergeConditions(updatedALBC.Status.Conditions, conditions...)
+		updated = haveConditionsChanged(controller.Status.Conditions, updatedALBC.Status.Conditions)
+	}
+
 	if updatedALBC.Status.Subnets == nil {
 		updatedALBC.Status.Subnets = &albo.AWSLoadBalancerControllerStatusSubnets{}
 		updated = true
@@ -172,6 +298,14 @@ func (r *AWSLoadBalancerControllerReconciler) updateStatusSubnets(ctx context.Co
 		updatedALBC.Status.Subnets.Untagged = untagged
 		updated = true
 	}
+	if !utils.EqualStrings(updatedALBC.Status.Subnets.Conflicting, conflicting) {
+		updatedALBC.Status.Subnets.Conflicting = conflicting
+		updated = true
+	}
+	if !equality.Semantic.DeepEqual(updatedALBC.Status.Subnets.Details, details) {
+		updatedALBC.Status.Subnets.Details = details
+		updated = true
+	}
 
 	if updated {
 		return r.Status().Update(ctx, updatedALBC)
@@ -179,12 +313,32 @@ func (r *AWSLoadBalancerControllerReconciler) updateStatusSubnets(ctx context.Co
 	return nil
 }
 
-func (r *AWSLoadBalancerControllerReconciler) updateStatusIngressClass(ctx context.Context, controller *albo.AWSLoadBalancerController, ingressClass string) error {
-	if controller.Status.IngressClass == ingressClass {
+// updateStatusLoadBalancers updates the load balancers status along with the given conditions if they changed.
+func (r *AWSLoadBalancerControllerReconciler) updateStatusLoadBalancers(ctx context.Context, controller *albo.AWSLoadBalancerController, loadBalancers []albo.AWSLoadBalancerStatus, conditions ...metav1.Condition) error {
+	updated := controller.DeepCopy()
+	updated.Status.Conditions = mergeConditions(updated.Status.Conditions, conditions...)
+	if equality.Semantic.DeepEqual(controller.Status.LoadBalancers, loadBalancers) && !haveConditionsChanged(controller.Status.Conditions, updated.Status.Conditions) {
+		return nil
+	}
+
+	updated.Status.LoadBalancers = loadBalancers
+	return r.Status().Update(ctx, updated)
+}
+
+func (r *AWSLoadBalancerControllerReconciler) updateStatusIngressClass(ctx context.Context, controller *albo.AWSLoadBalancerController, ingressClass string, ingressClasses []string) error {
+	if controller.Status.IngressClass == ingressClass && equality.Semantic.DeepEqual(controller.Status.IngressClasses, ingressClasses) {
 		return nil
 	}
 
 	updated := controller.DeepCopy()
 	updated.Status.IngressClass = ingressClass
+	updated.Status.IngressClasses = ingressClasses
+	return r.Status().Update(ctx, updated)
+}
+
+// updateStatusCredentialsRotationTime records the time of the rollout of the rotated credentials in the status.
+func (r *AWSLoadBalancerControllerReconciler) updateStatusCredentialsRotationTime(ctx context.Context, controller *albo.AWSLoadBalancerController, rotationTime metav1.Time) error {
+	updated := controller.DeepCopy()
+	updated.Status.CredentialsRotationTime = &rotationTime
 	return r.Status().Update(ctx, updated)
 }
@@ -19,31 +19,43 @@ import (
 
 func TestUpdateIngressClassStatus(t *testing.T) {
 	for _, tc := range []struct {
-		name              string
-		controller        *albo.AWSLoadBalancerController
-		inputIngressClass string
+		name                string
+		controller          *albo.AWSLoadBalancerController
+		inputIngressClass   string
+		inputIngressClasses []string
 	}{
 		{
 			name: "new class",
 			controller: &albo.AWSLoadBalancerController{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 			},
-			inputIngressClass: "alb",
+			inputIngressClass:   "alb",
+			inputIngressClasses: []string{"alb"},
 		},
 		{
 			name: "updated class",
 			controller: &albo.AWSLoadBalancerController{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
-				Status:     albo.AWSLoadBalancerControllerStatus{IngressClass: "alb"},
+				Status:     albo.AWSLoadBalancerControllerStatus{IngressClass: "alb", IngressClasses: []string{"alb"}},
 			},
-			inputIngressClass: "alb2",
+			inputIngressClass:   "alb2",
+			inputIngressClasses: []string{"alb2"},
+		},
+		{
+			name: "additional classes",
+			controller: &albo.AWSLoadBalancerController{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Status:     albo.AWSLoadBalancerControllerStatus{IngressClass: "alb", IngressClasses: []string{"alb"}},
+			},
+			inputIngressClass:   "alb",
+			inputIngressClasses: []string{"alb", "alb-internal", "alb-public"},
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			r := &AWSLoadBalancerControllerReconciler{
 				Client: fake.NewClientBuilder().WithScheme(test.Scheme).WithStatusSubresource(tc.controller).WithObjects(tc.controller).Build(),
 			}
-			err := r.updateStatusIngressClass(context.Background(), tc.controller, tc.inputIngressClass)
+			err := r.updateStatusIngressClass(context.Background(), tc.controller, tc.inputIngressClass, tc.inputIngressClasses)
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
@@ -54,6 +66,9 @@ func TestUpdateIngressClassStatus(t *testing.T) {
 			if controller.Status.IngressClass != tc.inputIngressClass {
 				t.Errorf("unexpected ingress class in status, expected %q, got %q", tc.inputIngressClass, controller.Status.IngressClass)
 			}
+			if diff := cmp.Diff(tc.inputIngressClasses, controller.Status.IngressClasses); diff != "" {
+				t.Errorf("unexpected ingress classes in status (-want +got):\n%s", diff)
+			}
 		})
 	}
 }
@@ -63,6 +78,7 @@ func TestUpdateSubnets(t *testing.T) {
 		name                               string
 		controller                         *albo.AWSLoadBalancerController
 		internal, public, untagged, tagged []string
+		details                            []albo.AWSLoadBalancerControllerSubnetDetails
 		taggingPolicy                      albo.SubnetTaggingPolicy
 	}{
 		{
@@ -70,10 +86,13 @@ func TestUpdateSubnets(t *testing.T) {
 			controller: &albo.AWSLoadBalancerController{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 			},
-			internal:      []string{"internal-1", "internal-2"},
-			public:        []string{"public-1", "public-2"},
-			tagged:        []string{"internal-1"},
-			untagged:      []string{"unknown-1"},
+			internal: []string{"internal-1", "internal-2"},
+			public:   []string{"public-1", "public-2"},
+			tagged:   []string{"internal-1"},
+			untagged: []string{"unknown-1"},
+			details: []albo.AWSLoadBalancerControllerSubnetDetails{
+				{ID: "internal-1", Role: albo.InternalSubnetRole, Reason: "route table rtb-1 has no default route"},
+			},
 			taggingPolicy: albo.ManualSubnetTaggingPolicy,
 		},
 		{
@@ -120,7 +139,7 @@ func TestUpdateSubnets(t *testing.T) {
 			r := &AWSLoadBalancerControllerReconciler{
 				Client: fake.NewClientBuilder().WithScheme(test.Scheme).WithStatusSubresource(tc.controller).WithObjects(tc.controller).Build(),
 			}
-			err := r.updateStatusSubnets(context.Background(), tc.controller, tc.internal, tc.public, tc.untagged, tc.tagged, tc.taggingPolicy)
+			err := r.updateStatusSubnets(context.Background(), tc.controller, tc.internal, tc.public, tc.untagged, tc.tagged, nil, tc.details, tc.taggingPolicy)
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
@@ -140,6 +159,9 @@ func TestUpdateSubnets(t *testing.T) {
 			if !equalStringSlices(tc.untagged, controller.Status.Subnets.Untagged) {
 				t.Errorf("unexpected untagged subnets, expected %v, got %v", tc.untagged, controller.Status.Subnets.Untagged)
 			}
+			if !cmp.Equal(tc.details, controller.Status.Subnets.Details, cmpopts.EquateEmpty()) {
+				t.Errorf("unexpected subnet details, expected %v, got %v", tc.details, controller.Status.Subnets.Details)
+			}
 			if tc.taggingPolicy != controller.Status.Subnets.SubnetTagging {
 				t.Errorf("unexpected tagging policy, expected %q, got %q", tc.taggingPolicy, controller.Status.Subnets.SubnetTagging)
 			}
@@ -161,6 +183,7 @@ func TestUpdateStatus(t *testing.T) {
 		deployment            *appsv1.Deployment
 		credentialsSecretName string
 		secretProvisioned     bool
+		secretErr             error
 		conditions            []metav1.Condition
 	}{
 		{
@@ -305,12 +328,33 @@ func TestUpdateStatus(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:                  "invalid credentials secret",
+			credentialsSecretName: "test",
+			secretProvisioned:     true,
+			secretErr: &credentialsSecretError{
+				reason:  "CredentialsKeyMissing",
+				message: `CredentialsSecret "test" does not have the "credentials" key`,
+			},
+			controller: &albo.AWSLoadBalancerController{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Generation: 5},
+			},
+			conditions: []metav1.Condition{
+				{
+					Type:               CredentialsSecretAvailableCondition,
+					Status:             metav1.ConditionFalse,
+					Reason:             "CredentialsKeyMissing",
+					Message:            `CredentialsSecret "test" does not have the "credentials" key`,
+					ObservedGeneration: 5,
+				},
+			},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			r := AWSLoadBalancerControllerReconciler{
 				Client: fake.NewClientBuilder().WithScheme(test.Scheme).WithStatusSubresource(tc.controller).WithObjects(tc.controller).Build(),
 			}
-			err := r.updateControllerStatus(context.Background(), tc.controller, tc.deployment, tc.credentialsSecretName, tc.secretProvisioned)
+			err := r.updateControllerStatus(context.Background(), tc.controller, tc.deployment, tc.credentialsSecretName, tc.secretProvisioned, tc.secretErr)
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
@@ -327,3 +371,179 @@ func TestUpdateStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestSubnetConditions(t *testing.T) {
+	for _, tc := range []struct {
+		name               string
+		details            []albo.AWSLoadBalancerControllerSubnetDetails
+		dualRoleSubnets    []string
+		selectionConflicts []string
+		minAvailableIPs    int32
+		conditions         []metav1.Condition
+	}{
+		{
+			name: "healthy subnets",
+			details: []albo.AWSLoadBalancerControllerSubnetDetails{
+				{ID: "subnet-1", Role: albo.PublicSubnetRole, AvailabilityZone: "us-east-1a", AvailableIPAddressCount: 100},
+				{ID: "subnet-2", Role: albo.PublicSubnetRole, AvailabilityZone: "us-east-1b", AvailableIPAddressCount: 100},
+				{ID: "subnet-3", Role: albo.InternalSubnetRole, AvailabilityZone: "us-east-1a", AvailableIPAddressCount: 8},
+				{ID: "subnet-4", Role: albo.InternalSubnetRole, AvailabilityZone: "us-east-1b", AvailableIPAddressCount: 100},
+				{ID: "subnet-5", AvailabilityZone: "us-east-1c", AvailableIPAddressCount: 0},
+			},
+			minAvailableIPs: 8,
+			conditions: []metav1.Condition{
+				{
+					Type:               SubnetsReadyCondition,
+					Status:             metav1.ConditionTrue,
+					Reason:             "SubnetsHealthy",
+					Message:            "Subnets are ready to host load balancers",
+					ObservedGeneration: 5,
+				},
+				{
+					Type:               SubnetsDegradedCondition,
+					Status:             metav1.ConditionFalse,
+					Reason:             "SubnetsHealthy",
+					Message:            "Subnets are ready to host load balancers",
+					ObservedGeneration: 5,
+				},
+			},
+		},
+		{
+			name: "single availability zone and low available IP addresses",
+			details: []albo.AWSLoadBalancerControllerSubnetDetails{
+				{ID: "subnet-1", Role: albo.PublicSubnetRole, AvailabilityZone: "us-east-1a", AvailableIPAddressCount: 3},
+				{ID: "subnet-2", Role: albo.PublicSubnetRole, AvailabilityZone: "us-east-1a", AvailableIPAddressCount: 100},
+				{ID: "subnet-3", Role: albo.InternalSubnetRole, AvailabilityZone: "us-east-1a", AvailableIPAddressCount: 0},
+				{ID: "subnet-4", Role: albo.InternalSubnetRole, AvailabilityZone: "us-east-1b", AvailableIPAddressCount: 100},
+			},
+			minAvailableIPs: 8,
+			conditions: []metav1.Condition{
+				{
+					Type:               SubnetsReadyCondition,
+					Status:             metav1.ConditionFalse,
+					Reason:             "InsufficientAvailabilityZones",
+					Message:            "Public subnets span only availability zones us-east-1a, at least 2 availability zones are required by application load balancers; Subnets subnet-1 (3), subnet-3 (0) have less than 8 available IP addresses",
+					ObservedGeneration: 5,
+				},
+				{
+					Type:               SubnetsDegradedCondition,
+					Status:             metav1.ConditionTrue,
+					Reason:             "InsufficientAvailabilityZones",
+					Message:            "Public subnets span only availability zones us-east-1a, at least 2 availability zones are required by application load balancers; Subnets subnet-1 (3), subnet-3 (0) have less than 8 available IP addresses",
+					ObservedGeneration: 5,
+				},
+			},
+		},
+		{
+			name: "available IP addresses check disabled",
+			details: []albo.AWSLoadBalancerControllerSubnetDetails{
+				{ID: "subnet-1", Role: albo.PublicSubnetRole, AvailabilityZone: "us-east-1a", AvailableIPAddressCount: 0},
+				{ID: "subnet-2", Role: albo.PublicSubnetRole, AvailabilityZone: "us-east-1b", AvailableIPAddressCount: 0},
+				{ID: "subnet-3", Role: albo.InternalSubnetRole, AvailabilityZone: "us-east-1a", AvailableIPAddressCount: 0},
+				{ID: "subnet-4", Role: albo.InternalSubnetRole, AvailabilityZone: "us-east-1b", AvailableIPAddressCount: 0},
+			},
+			minAvailableIPs: 0,
+			conditions: []metav1.Condition{
+				{
+					Type:               SubnetsReadyCondition,
+					Status:             metav1.ConditionTrue,
+					Reason:             "SubnetsHealthy",
+					Message:            "Subnets are ready to host load balancers",
+					ObservedGeneration: 5,
+				},
+				{
+					Type:               SubnetsDegradedCondition,
+					Status:             metav1.ConditionFalse,
+					Reason:             "SubnetsHealthy",
+					Message:            "Subnets are ready to host load balancers",
+					ObservedGeneration: 5,
+				},
+			},
+		},
+		{
+			name: "no internal subnets",
+			details: []albo.AWSLoadBalancerControllerSubnetDetails{
+				{ID: "subnet-1", Role: albo.PublicSubnetRole, AvailabilityZone: "us-east-1a", AvailableIPAddressCount: 100},
+				{ID: "subnet-2", Role: albo.PublicSubnetRole, AvailabilityZone: "us-east-1b", AvailableIPAddressCount: 100},
+				{ID: "subnet-3", AvailabilityZone: "us-east-1c", AvailableIPAddressCount: 100},
+			},
+			minAvailableIPs: 8,
+			conditions: []metav1.Condition{
+				{
+					Type:               SubnetsReadyCondition,
+					Status:             metav1.ConditionFalse,
+					Reason:             "InsufficientAvailabilityZones",
+					Message:            "Internal subnets span no availability zone, at least 2 availability zones are required by application load balancers",
+					ObservedGeneration: 5,
+				},
+				{
+					Type:               SubnetsDegradedCondition,
+					Status:             metav1.ConditionTrue,
+					Reason:             "InsufficientAvailabilityZones",
+					Message:            "Internal subnets span no availability zone, at least 2 availability zones are required by application load balancers",
+					ObservedGeneration: 5,
+				},
+			},
+		},
+		{
+			name:            "subnets with both role tags",
+			details:         testHealthySubnetDetails(),
+			dualRoleSubnets: []string{"subnet-1", "subnet-2"},
+			minAvailableIPs: 8,
+			conditions: []metav1.Condition{
+				{
+					Type:               SubnetsReadyCondition,
+					Status:             metav1.ConditionFalse,
+					Reason:             "DualRoleSubnets",
+					Message:            "Subnets subnet-1, subnet-2 have both tags kubernetes.io/role/internal-elb and kubernetes.io/role/elb",
+					ObservedGeneration: 5,
+				},
+				{
+					Type:               SubnetsDegradedCondition,
+					Status:             metav1.ConditionTrue,
+					Reason:             "DualRoleSubnets",
+					Message:            "Subnets subnet-1, subnet-2 have both tags kubernetes.io/role/internal-elb and kubernetes.io/role/elb",
+					ObservedGeneration: 5,
+				},
+			},
+		},
+		{
+			name:               "selected subnets in conflict",
+			details:            testHealthySubnetDetails(),
+			selectionConflicts: []string{"subnets [subnet-1] are selected as both public and internal"},
+			minAvailableIPs:    8,
+			conditions: []metav1.Condition{
+				{
+					Type:               SubnetsReadyCondition,
+					Status:             metav1.ConditionFalse,
+					Reason:             "SubnetSelectionConflict",
+					Message:            "Selected subnets are not tagged: subnets [subnet-1] are selected as both public and internal",
+					ObservedGeneration: 5,
+				},
+				{
+					Type:               SubnetsDegradedCondition,
+					Status:             metav1.ConditionTrue,
+					Reason:             "SubnetSelectionConflict",
+					Message:            "Selected subnets are not tagged: subnets [subnet-1] are selected as both public and internal",
+					ObservedGeneration: 5,
+				},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			conditions := subnetConditions(tc.details, tc.dualRoleSubnets, tc.selectionConflicts, tc.minAvailableIPs, 5)
+			if diff := cmp.Diff(tc.conditions, conditions); diff != "" {
+				t.Errorf("unexpected conditions (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func testHealthySubnetDetails() []albo.AWSLoadBalancerControllerSubnetDetails {
+	return []albo.AWSLoadBalancerControllerSubnetDetails{
+		{ID: "subnet-1", Role: albo.PublicSubnetRole, AvailabilityZone: "us-east-1a", AvailableIPAddressCount: 100},
+		{ID: "subnet-2", Role: albo.PublicSubnetRole, AvailabilityZone: "us-east-1b", AvailableIPAddressCount: 100},
+		{ID: "subnet-3", Role: albo.InternalSubnetRole, AvailabilityZone: "us-east-1a", AvailableIPAddressCount: 100},
+		{ID: "subnet-4", Role: albo.InternalSubnetRole, AvailabilityZone: "us-east-1b", AvailableIPAddressCount: 100},
+	}
+}
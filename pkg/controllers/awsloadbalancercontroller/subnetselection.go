@@ -0,0 +1,197 @@
+package awsloadbalancercontroller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	albo "github.com/openshift/aws-load-balancer-operator/api/v1"
+)
+
+const (
+	subnetIDFilterName         = "subnet-id"
+	availabilityZoneFilterName = "availability-zone"
+	tagFilterNamePrefix        = "tag:"
+)
+
+// resolveSubnetSelector returns the subnets matching the given selector.
+// The subnets are looked up in the cluster VPC unless the selector specifies subnet IDs.
+// The subnet IDs which are not found, the found subnets which don't belong to the cluster VPC
+// and the selector matching no subnet are described in the returned problems, the subnets outside
+// of the cluster VPC are left out of the selection. An error is returned only if the subnets cannot be listed.
+func (r *AWSLoadBalancerControllerReconciler) resolveSubnetSelector(ctx context.Context, selector *albo.SubnetSelector) ([]ec2types.Subnet, []string, error) {
+	var filters []ec2types.Filter
+	if len(selector.IDs) > 0 {
+		ids := make([]string, 0, len(selector.IDs))
+		for _, id := range selector.IDs {
+			ids = append(ids, string(id))
+		}
+		filters = append(filters, ec2types.Filter{
+			Name:   aws.String(subnetIDFilterName),
+			Values: ids,
+		})
+	} else {
+		filters = append(filters, ec2types.Filter{
+			Name:   aws.String(vpcIDFilterName),
+			Values: []string{r.VPCID},
+		})
+	}
+	if len(selector.AvailabilityZones) > 0 {
+		filters = append(filters, ec2types.Filter{
+			Name:   aws.String(availabilityZoneFilterName),
+			Values: selector.AvailabilityZones,
+		})
+	}
+	for _, tag := range selector.Tags {
+		if tag.Value == "" {
+			filters = append(filters, ec2types.Filter{
+				Name:   aws.String(tagKeyFilterName),
+				Values: []string{tag.Key},
+			})
+		} else {
+			filters = append(filters, ec2types.Filter{
+				Name:   aws.String(tagFilterNamePrefix + tag.Key),
+				Values: []string{tag.Value},
+			})
+		}
+	}
+
+	var subnets []ec2types.Subnet
+	subnetsPaginator := ec2.NewDescribeSubnetsPaginator(r.EC2Client, &ec2.DescribeSubnetsInput{Filters: filters})
+	for subnetsPaginator.HasMorePages() {
+		response, err := subnetsPaginator.NextPage(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list subnets: %w", err)
+		}
+		subnets = append(subnets, response.Subnets...)
+	}
+
+	var selected []ec2types.Subnet
+	var problems []string
+	found := sets.New[string]()
+	for _, s := range subnets {
+		subnetID := aws.ToString(s.SubnetId)
+		found.Insert(subnetID)
+		if vpcID := aws.ToString(s.VpcId); vpcID != r.VPCID {
+			problems = append(problems, fmt.Sprintf("subnet %s belongs to vpc %s instead of the cluster vpc %s", subnetID, vpcID, r.VPCID))
+			continue
+		}
+		selected = append(selected, s)
+	}
+	for _, id := range selector.IDs {
+		if !found.Has(string(id)) {
+			problems = append(problems, fmt.Sprintf("subnet %s not found", id))
+		}
+	}
+	if len(subnets) == 0 {
+		problems = append(problems, "no subnets match the selector")
+	}
+	return selected, problems, nil
+}
+
+// resolveSubnetSelection returns the subnets selected by the public and internal selectors of the given spec.
+// The problems of the selectors are returned prefixed with the field of the selector.
+func (r *AWSLoadBalancerControllerReconciler) resolveSubnetSelection(ctx context.Context, selection *albo.AWSLoadBalancerControllerSubnets) (selectedPublic, selectedInternal []ec2types.Subnet, problems []string, err error) {
+	if selection.Public != nil {
+		var publicProblems []string
+		selectedPublic, publicProblems, err = r.resolveSubnetSelector(ctx, selection.Public)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to resolve public subnets: %w", err)
+		}
+		for _, problem := range publicProblems {
+			problems = append(problems, "spec.subnets.public: "+problem)
+		}
+	}
+	if selection.Internal != nil {
+		var internalProblems []string
+		selectedInternal, internalProblems, err = r.resolveSubnetSelector(ctx, selection.Internal)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to resolve internal subnets: %w", err)
+		}
+		for _, problem := range internalProblems {
+			problems = append(problems, "spec.subnets.internal: "+problem)
+		}
+	}
+	return selectedPublic, selectedInternal, problems, nil
+}
+
+// tagSelectedSubnets tags the selected subnets with the role of their selector.
+// The subnets tagged by the operator which are not selected anymore are untagged.
+// The given sets are updated with the resulting tagging of the subnets.
+// The subnets which cannot get the role of their selector are left out of the selection,
+// the returned selection conflicts describe them.
+func (r *AWSLoadBalancerControllerReconciler) tagSelectedSubnets(ctx context.Context, selectedPublic, selectedInternal, internal, public, tagged, untagged sets.Set[string], reasons map[string]string) ([]string, error) {
+	var selectionConflicts []string
+	if both := selectedPublic.Intersection(selectedInternal); both.Len() > 0 {
+		selectionConflicts = append(selectionConflicts, fmt.Sprintf("subnets %v are selected as both public and internal", sets.List(both)))
+		selectedPublic = selectedPublic.Difference(both)
+		selectedInternal = selectedInternal.Difference(both)
+	}
+	// the role tags put by the user are never changed
+	if conflicting := selectedPublic.Intersection(internal.Difference(tagged)); conflicting.Len() > 0 {
+		selectionConflicts = append(selectionConflicts, fmt.Sprintf("subnets %v are selected as public but have tag %s put by the user", sets.List(conflicting), internalELBTagKey))
+		selectedPublic = selectedPublic.Difference(conflicting)
+	}
+	if conflicting := selectedInternal.Intersection(public.Difference(tagged)); conflicting.Len() > 0 {
+		selectionConflicts = append(selectionConflicts, fmt.Sprintf("subnets %v are selected as internal but have tag %s put by the user", sets.List(conflicting), publicELBTagKey))
+		selectedInternal = selectedInternal.Difference(conflicting)
+	}
+
+	unselected := tagged.Difference(selectedPublic).Difference(selectedInternal)
+	if unselected.Len() > 0 {
+		if err := r.removeOperatorTags(ctx, sets.List(unselected)); err != nil {
+			return nil, err
+		}
+	}
+
+	tagPublic := selectedPublic.Difference(public)
+	tagInternal := selectedInternal.Difference(internal)
+	if tagPublic.Len() > 0 {
+		if err := r.createRoleTags(ctx, sets.List(tagPublic), publicELBTagKey); err != nil {
+			return nil, err
+		}
+	}
+	if tagInternal.Len() > 0 {
+		if err := r.createRoleTags(ctx, sets.List(tagInternal), internalELBTagKey); err != nil {
+			return nil, err
+		}
+	}
+	// the subnets tagged by the operator which were moved to the other selector
+	if retagPublic := tagPublic.Intersection(internal); retagPublic.Len() > 0 {
+		if err := r.deleteRoleTag(ctx, sets.List(retagPublic), internalELBTagKey); err != nil {
+			return nil, err
+		}
+		internal.Delete(sets.List(retagPublic)...)
+	}
+	if retagInternal := tagInternal.Intersection(public); retagInternal.Len() > 0 {
+		if err := r.deleteRoleTag(ctx, sets.List(retagInternal), publicELBTagKey); err != nil {
+			return nil, err
+		}
+		public.Delete(sets.List(retagInternal)...)
+	}
+
+	internal.Delete(sets.List(unselected)...)
+	public.Delete(sets.List(unselected)...)
+	tagged.Delete(sets.List(unselected)...)
+	untagged.Insert(sets.List(unselected)...)
+
+	public.Insert(sets.List(selectedPublic)...)
+	internal.Insert(sets.List(selectedInternal)...)
+	tagged.Insert(sets.List(tagPublic)...)
+	tagged.Insert(sets.List(tagInternal)...)
+	untagged.Delete(sets.List(selectedPublic)...)
+	untagged.Delete(sets.List(selectedInternal)...)
+
+	for _, id := range sets.List(selectedPublic.Intersection(tagged)) {
+		reasons[id] = "subnet is selected by spec.subnets.public"
+	}
+	for _, id := range sets.List(selectedInternal.Intersection(tagged)) {
+		reasons[id] = "subnet is selected by spec.subnets.internal"
+	}
+	return selectionConflicts, nil
+}
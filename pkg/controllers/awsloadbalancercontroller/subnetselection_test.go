@@ -0,0 +1,150 @@
+package awsloadbalancercontroller
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	awstypes "github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	albo "github.com/openshift/aws-load-balancer-operator/api/v1"
+	"github.com/openshift/aws-load-balancer-operator/pkg/utils"
+)
+
+func TestResolveSubnetSelector(t *testing.T) {
+	accountSubnets := []ec2types.Subnet{
+		testAccountSubnet("subnet-1", "test-vpc", "us-east-1a", ec2types.Tag{Key: awstypes.String("tier"), Value: awstypes.String("public")}),
+		testAccountSubnet("subnet-2", "test-vpc", "us-east-1b", ec2types.Tag{Key: awstypes.String("tier"), Value: awstypes.String("private")}),
+		testAccountSubnet("subnet-3", "test-vpc", "us-east-1b"),
+		testAccountSubnet("subnet-4", "other-vpc", "us-east-1a", ec2types.Tag{Key: awstypes.String("tier"), Value: awstypes.String("public")}),
+	}
+	for _, tc := range []struct {
+		name             string
+		selector         *albo.SubnetSelector
+		expectedSubnets  []string
+		expectedProblems []string
+	}{
+		{
+			name:            "ids",
+			selector:        &albo.SubnetSelector{IDs: []albo.SubnetID{"subnet-1", "subnet-3"}},
+			expectedSubnets: []string{"subnet-1", "subnet-3"},
+		},
+		{
+			name:             "ids and availability zones",
+			selector:         &albo.SubnetSelector{IDs: []albo.SubnetID{"subnet-1", "subnet-3"}, AvailabilityZones: []string{"us-east-1b"}},
+			expectedSubnets:  []string{"subnet-3"},
+			expectedProblems: []string{"subnet subnet-1 not found"},
+		},
+		{
+			name:            "availability zones",
+			selector:        &albo.SubnetSelector{AvailabilityZones: []string{"us-east-1a"}},
+			expectedSubnets: []string{"subnet-1"},
+		},
+		{
+			name:            "tag key",
+			selector:        &albo.SubnetSelector{Tags: []albo.SubnetTagSelector{{Key: "tier"}}},
+			expectedSubnets: []string{"subnet-1", "subnet-2"},
+		},
+		{
+			name:            "tag key and value",
+			selector:        &albo.SubnetSelector{Tags: []albo.SubnetTagSelector{{Key: "tier", Value: "private"}}},
+			expectedSubnets: []string{"subnet-2"},
+		},
+		{
+			name:             "subnet not found",
+			selector:         &albo.SubnetSelector{IDs: []albo.SubnetID{"subnet-1", "subnet-9"}},
+			expectedSubnets:  []string{"subnet-1"},
+			expectedProblems: []string{"subnet subnet-9 not found"},
+		},
+		{
+			name:             "subnet outside of the cluster vpc",
+			selector:         &albo.SubnetSelector{IDs: []albo.SubnetID{"subnet-1", "subnet-4"}},
+			expectedSubnets:  []string{"subnet-1"},
+			expectedProblems: []string{"subnet subnet-4 belongs to vpc other-vpc instead of the cluster vpc test-vpc"},
+		},
+		{
+			name:             "no matching subnets",
+			selector:         &albo.SubnetSelector{AvailabilityZones: []string{"us-east-1c"}},
+			expectedProblems: []string{"no subnets match the selector"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &AWSLoadBalancerControllerReconciler{
+				EC2Client: &testEC2Client{
+					t:              t,
+					accountSubnets: accountSubnets,
+				},
+				VPCID: "test-vpc",
+			}
+			subnets, problems, err := r.resolveSubnetSelector(context.Background(), tc.selector)
+			if err != nil {
+				t.Fatalf("got unexpected error: %v", err)
+			}
+			if got := sets.List(subnetIDs(subnets)); !utils.EqualStrings(tc.expectedSubnets, got) {
+				t.Errorf("expected subnets %v, got %v", tc.expectedSubnets, got)
+			}
+			if !utils.EqualStrings(tc.expectedProblems, problems) {
+				t.Errorf("expected problems %v, got %v", tc.expectedProblems, problems)
+			}
+		})
+	}
+}
+
+func TestTagSelectedSubnetsConflicts(t *testing.T) {
+	for _, tc := range []struct {
+		name                       string
+		selectedPublic             []string
+		selectedInternal           []string
+		internal                   []string
+		public                     []string
+		tagged                     []string
+		expectedSelectionConflicts []string
+		expectedPublic             []string
+		expectedInternal           []string
+	}{
+		{
+			name:                       "subnet selected as public and internal",
+			selectedPublic:             []string{"subnet-1", "subnet-2"},
+			selectedInternal:           []string{"subnet-2"},
+			expectedSelectionConflicts: []string{"subnets [subnet-2] are selected as both public and internal"},
+			expectedPublic:             []string{"subnet-1"},
+		},
+		{
+			name:                       "subnet selected as public has user internal tag",
+			selectedPublic:             []string{"subnet-1", "subnet-2"},
+			internal:                   []string{"subnet-1"},
+			expectedSelectionConflicts: []string{"subnets [subnet-1] are selected as public but have tag kubernetes.io/role/internal-elb put by the user"},
+			expectedPublic:             []string{"subnet-2"},
+			expectedInternal:           []string{"subnet-1"},
+		},
+		{
+			name:                       "subnet selected as internal has user public tag",
+			selectedInternal:           []string{"subnet-1"},
+			public:                     []string{"subnet-1"},
+			expectedSelectionConflicts: []string{"subnets [subnet-1] are selected as internal but have tag kubernetes.io/role/elb put by the user"},
+			expectedPublic:             []string{"subnet-1"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &AWSLoadBalancerControllerReconciler{
+				EC2Client: &testEC2Client{t: t},
+			}
+			internal, public := sets.New(tc.internal...), sets.New(tc.public...)
+			selectionConflicts, err := r.tagSelectedSubnets(context.Background(), sets.New(tc.selectedPublic...), sets.New(tc.selectedInternal...), internal, public, sets.New(tc.tagged...), sets.New[string](), map[string]string{})
+			if err != nil {
+				t.Fatalf("got unexpected error: %v", err)
+			}
+			if !utils.EqualStrings(tc.expectedSelectionConflicts, selectionConflicts) {
+				t.Errorf("expected selection conflicts %v, got %v", tc.expectedSelectionConflicts, selectionConflicts)
+			}
+			if got := sets.List(public); !utils.EqualStrings(tc.expectedPublic, got) {
+				t.Errorf("expected public subnets %v, got %v", tc.expectedPublic, got)
+			}
+			if got := sets.List(internal); !utils.EqualStrings(tc.expectedInternal, got) {
+				t.Errorf("expected internal subnets %v, got %v", tc.expectedInternal, got)
+			}
+		})
+	}
+}
@@ -2,8 +2,13 @@ package awsloadbalancercontroller
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -19,32 +24,170 @@ const (
 	publicELBTagKey    = "kubernetes.io/role/elb"
 	tagKeyFilterName   = "tag-key"
 	tagKeyALBOTagged   = "networking.olm.openshift.io/albo/tagged"
+
+	vpcIDFilterName             = "vpc-id"
+	attachmentVPCIDFilterName   = "attachment.vpc-id"
+	ipv4DefaultRouteDestination = "0.0.0.0/0"
+	ipv6DefaultRouteDestination = "::/0"
+
+	// the zone types returned by DescribeAvailabilityZones
+	availabilityZoneType = "availability-zone"
+	localZoneType        = "local-zone"
+	wavelengthZoneType   = "wavelength-zone"
+
+	// defaultSubnetResyncInterval is the subnet resync interval used when the spec doesn't set any.
+	defaultSubnetResyncInterval = 10 * time.Minute
+	// defaultMinSubnetAvailableIPAddresses is the minimum number of available IP addresses per subnet
+	// used when the spec doesn't set any. An application load balancer requires at least 8 free IP addresses per subnet.
+	defaultMinSubnetAvailableIPAddresses int32 = 8
+	// subnetsChangedEventReason is the reason of the event emitted when the cluster subnets change.
+	subnetsChangedEventReason = "SubnetsChanged"
 )
 
+// syncSubnets rediscovers and tags the cluster subnets if they have not yet been written into the status,
+// if the tagging policy has changed or if the resync interval has elapsed since the last sync.
+// The subnets status is updated only when it changed, an event listing the added, removed
+// and re-tagged subnets is emitted in this case. The returned duration is the time left until the next sync.
+func (r *AWSLoadBalancerControllerReconciler) syncSubnets(ctx context.Context, controller *albo.AWSLoadBalancerController) (synced bool, nextSync time.Duration, err error) {
+	interval := defaultSubnetResyncInterval
+	if controller.Spec.SubnetResyncInterval != nil && controller.Spec.SubnetResyncInterval.Duration > 0 {
+		interval = controller.Spec.SubnetResyncInterval.Duration
+	}
+
+	specHash, err := subnetSpecHash(controller)
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to build hash of subnet spec: %w", err)
+	}
+	sinceLastSync := time.Since(r.subnetsSyncedAt)
+	if controller.Status.Subnets != nil && controller.Spec.SubnetTagging == controller.Status.Subnets.SubnetTagging && specHash == r.subnetsSyncedHash && sinceLastSync < interval {
+		return false, interval - sinceLastSync, nil
+	}
+
+	minAvailableIPs := defaultMinSubnetAvailableIPAddresses
+	if controller.Spec.MinSubnetAvailableIPAddresses != nil {
+		minAvailableIPs = *controller.Spec.MinSubnetAvailableIPAddresses
+	}
+
+	internalSubnets, publicSubnets, untaggedSubnets, taggedSubnets, conflictingSubnets, subnetDetails, selectionConflicts, err := r.tagSubnets(ctx, controller)
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to update subnets: %w", err)
+	}
+
+	if message := subnetChangesMessage(controller.Status.Subnets, internalSubnets, publicSubnets, untaggedSubnets, conflictingSubnets); message != "" {
+		r.Recorder.Event(controller, corev1.EventTypeNormal, subnetsChangedEventReason, message)
+	}
+
+	conditions := subnetConditions(subnetDetails, conflictingSubnets, selectionConflicts, minAvailableIPs, controller.Generation)
+	if err = r.updateStatusSubnets(ctx, controller, internalSubnets, publicSubnets, untaggedSubnets, taggedSubnets, conflictingSubnets, subnetDetails, controller.Spec.SubnetTagging, conditions...); err != nil {
+		return false, 0, fmt.Errorf("failed to update status with subnets: %w", err)
+	}
+
+	r.subnetsSyncedAt, r.subnetsSyncedHash = time.Now(), specHash
+	return true, interval, nil
+}
+
+// subnetSpecHash returns a checksum of the spec fields which change the tagging or the conditions of the subnets.
+func subnetSpecHash(controller *albo.AWSLoadBalancerController) (string, error) {
+	spec, err := json.Marshal(struct {
+		Subnets                       *albo.AWSLoadBalancerControllerSubnets
+		SubnetConflictPolicy          albo.SubnetConflictPolicy
+		MinSubnetAvailableIPAddresses *int32
+	}{
+		Subnets:                       controller.Spec.Subnets,
+		SubnetConflictPolicy:          controller.Spec.SubnetConflictPolicy,
+		MinSubnetAvailableIPAddresses: controller.Spec.MinSubnetAvailableIPAddresses,
+	})
+	if err != nil {
+		return "", err
+	}
+	return buildMapHash(map[string]string{"spec": string(spec)})
+}
+
+// subnetChangesMessage returns a message listing the subnets added, removed or re-tagged
+// compared to the given subnets status. An empty string is returned if nothing changed.
+func subnetChangesMessage(current *albo.AWSLoadBalancerControllerStatusSubnets, internal, public, untagged, conflicting []string) string {
+	currentRoles := map[string]string{}
+	if current != nil {
+		currentRoles = subnetRoles(current.Internal, current.Public, current.Untagged, current.Conflicting)
+	}
+	roles := subnetRoles(internal, public, untagged, conflicting)
+
+	var added, removed, retagged []string
+	for id, role := range roles {
+		currentRole, found := currentRoles[id]
+		switch {
+		case !found:
+			added = append(added, id)
+		case currentRole != role:
+			retagged = append(retagged, fmt.Sprintf("%s (%s -> %s)", id, currentRole, role))
+		}
+	}
+	for id := range currentRoles {
+		if _, found := roles[id]; !found {
+			removed = append(removed, id)
+		}
+	}
+
+	var changes []string
+	for _, change := range []struct {
+		name    string
+		subnets []string
+	}{
+		{name: "added", subnets: added},
+		{name: "removed", subnets: removed},
+		{name: "re-tagged", subnets: retagged},
+	} {
+		if len(change.subnets) > 0 {
+			sort.Strings(change.subnets)
+			changes = append(changes, fmt.Sprintf("%s: %s", change.name, strings.Join(change.subnets, ", ")))
+		}
+	}
+	if len(changes) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Cluster subnets changed; %s", strings.Join(changes, "; "))
+}
+
+// subnetRoles returns the role of each of the given subnets.
+// The untagged subnets get "Untagged" role, the conflicting subnets get "Conflicting" role.
+func subnetRoles(internal, public, untagged, conflicting []string) map[string]string {
+	roles := map[string]string{}
+	for _, id := range untagged {
+		roles[id] = "Untagged"
+	}
+	for _, id := range conflicting {
+		roles[id] = "Conflicting"
+	}
+	for _, id := range internal {
+		roles[id] = string(albo.InternalSubnetRole)
+	}
+	for _, id := range public {
+		roles[id] = string(albo.PublicSubnetRole)
+	}
+	return roles
+}
+
 // tagSubnets will add detect the subnets of the cluster and then tag them appropriately. It then writes the detected
 // subnet IDs into the status along with their tagged roles.
-func (r *AWSLoadBalancerControllerReconciler) tagSubnets(ctx context.Context, controller *albo.AWSLoadBalancerController) (internalSubnets, publicSubnets, untaggedSubnets, taggedSubnets []string, err error) {
-	// list the subnets which are tagged as owned by the cluster
-	subnetsPaginator := ec2.NewDescribeSubnetsPaginator(r.EC2Client, &ec2.DescribeSubnetsInput{
-		Filters: []ec2types.Filter{
-			{
-				Name:   aws.String(tagKeyFilterName),
-				Values: []string{fmt.Sprintf(clusterOwnedTagKey, r.ClusterName)},
-			},
-		},
-	})
+// The subnets having both role tags are returned as conflicting subnets, they are not tagged by the operator.
+// The subnets of spec.subnets which cannot be resolved or cannot get the role of their selector
+// are described in the returned selection conflicts.
+func (r *AWSLoadBalancerControllerReconciler) tagSubnets(ctx context.Context, controller *albo.AWSLoadBalancerController) (internalSubnets, publicSubnets, untaggedSubnets, taggedSubnets, conflictingSubnets []string, subnetDetails []albo.AWSLoadBalancerControllerSubnetDetails, selectionConflicts []string, err error) {
+	subnets, err := r.describeClusterSubnets(ctx)
+	if err != nil {
+		return
+	}
 
-	var (
-		subnets  []ec2types.Subnet
-		response *ec2.DescribeSubnetsOutput
-	)
-	for subnetsPaginator.HasMorePages() {
-		response, err = subnetsPaginator.NextPage(ctx)
+	// the selected subnets are taken into account along with the cluster subnets
+	var selectedPublic, selectedInternal []ec2types.Subnet
+	var selectionProblems []string
+	if controller.Spec.SubnetTagging == albo.AutoSubnetTaggingPolicy && controller.Spec.Subnets != nil {
+		selectedPublic, selectedInternal, selectionProblems, err = r.resolveSubnetSelection(ctx, controller.Spec.Subnets)
 		if err != nil {
-			err = fmt.Errorf("failed to list subnets for cluster id %s: %w", r.ClusterName, err)
+			err = fmt.Errorf("failed to resolve selected subnets: %w", err)
 			return
 		}
-		subnets = append(subnets, response.Subnets...)
+		subnets = mergeSubnets(subnets, selectedPublic, selectedInternal)
 	}
 
 	if len(subnets) == 0 {
@@ -52,44 +195,77 @@ func (r *AWSLoadBalancerControllerReconciler) tagSubnets(ctx context.Context, co
 		return
 	}
 
-	var (
-		internal sets.Set[string]
-		public   sets.Set[string]
-		untagged sets.Set[string]
-		tagged   sets.Set[string]
-	)
+	internal, public, tagged, untagged, conflicting := classifySubnets(subnets)
 
-	internal, public, tagged, untagged, err = classifySubnets(subnets)
-	if err != nil {
-		err = fmt.Errorf("failed to classify subnets of cluster %s: %w", r.ClusterName, err)
-		return
+	// reasons holds the explanation of the role for the subnets tagged by the operator
+	reasons := map[string]string{}
+
+	if controller.Spec.SubnetTagging == albo.AutoSubnetTaggingPolicy && controller.Spec.SubnetConflictPolicy == albo.RemoveOperatorTagSubnetConflictPolicy && conflicting.Len() > 0 {
+		if err = r.resolveConflictingSubnets(ctx, controller, subnets, subnetIDs(selectedPublic), subnetIDs(selectedInternal), internal, public, conflicting, reasons); err != nil {
+			err = fmt.Errorf("failed to resolve conflicting subnets %v: %w", sets.List(conflicting), err)
+			return
+		}
 	}
 
 	switch controller.Spec.SubnetTagging {
 	case albo.AutoSubnetTaggingPolicy:
-		// in OpenShift all private subnets are tagged. So assume any untagged subnets are public
-		// TODO: process the subnets based on whether they have attached internet gateways
-		if untagged.Len() > 0 {
-			_, err = r.EC2Client.CreateTags(ctx, &ec2.CreateTagsInput{
-				Resources: sets.List(untagged),
-				Tags: []ec2types.Tag{
-					{
-						Key:   aws.String(publicELBTagKey),
-						Value: aws.String("1"),
-					},
-					{
-						Key:   aws.String(tagKeyALBOTagged),
-						Value: aws.String("1"),
-					},
-				},
-			})
+		if controller.Spec.Subnets != nil {
+			// only the selected subnets are tagged when the subnets are explicitly selected
+			// the conflicting subnets are not tagged even if they are selected
+			if selectionConflicts, err = r.tagSelectedSubnets(ctx, subnetIDs(selectedPublic).Difference(conflicting), subnetIDs(selectedInternal).Difference(conflicting), internal, public, tagged, untagged, reasons); err != nil {
+				err = fmt.Errorf("failed to tag selected subnets: %w", err)
+				return
+			}
+			selectionConflicts = append(selectionProblems, selectionConflicts...)
+			break
+		}
+		// the role of the untagged subnets and of the subnets previously tagged by the operator
+		// is detected from their route tables: only the subnets which route to an internet gateway are public
+		if candidates := untagged.Union(tagged); candidates.Len() > 0 {
+			var roles map[string]subnetRole
+			roles, err = r.discoverSubnetRoles(ctx, sets.List(candidates))
 			if err != nil {
-				err = fmt.Errorf("failed to tag subnets %v: %w", untaggedSubnets, err)
+				err = fmt.Errorf("failed to discover roles of subnets %v: %w", sets.List(candidates), err)
 				return
 			}
+			discoveredPublic, discoveredInternal := sets.New[string](), sets.New[string]()
+			for id, role := range roles {
+				if role.tagKey == publicELBTagKey {
+					discoveredPublic.Insert(id)
+				} else {
+					discoveredInternal.Insert(id)
+				}
+				reasons[id] = role.reason
+			}
+			// the subnets tagged by the operator whose role changed since the last tagging
+			retagPublic := tagged.Intersection(internal).Intersection(discoveredPublic)
+			retagInternal := tagged.Intersection(public).Intersection(discoveredInternal)
+			// only the subnets which don't have the discovered role tag yet need to be tagged
+			tagPublic := discoveredPublic.Difference(public)
+			tagInternal := discoveredInternal.Difference(internal)
+			if tagPublic.Len() > 0 {
+				if err = r.createRoleTags(ctx, sets.List(tagPublic), publicELBTagKey); err != nil {
+					return
+				}
+			}
+			if tagInternal.Len() > 0 {
+				if err = r.createRoleTags(ctx, sets.List(tagInternal), internalELBTagKey); err != nil {
+					return
+				}
+			}
+			if retagPublic.Len() > 0 {
+				if err = r.deleteRoleTag(ctx, sets.List(retagPublic), internalELBTagKey); err != nil {
+					return
+				}
+			}
+			if retagInternal.Len() > 0 {
+				if err = r.deleteRoleTag(ctx, sets.List(retagInternal), publicELBTagKey); err != nil {
+					return
+				}
+			}
+			public = public.Difference(retagInternal).Union(discoveredPublic)
+			internal = internal.Difference(retagPublic).Union(discoveredInternal)
 		}
-		// the untagged subnets are now public subnets
-		public = public.Union(untagged)
 		// marked the untagged subnets as now tagged
 		tagged = tagged.Union(untagged)
 		// there are no untagged subnets now
@@ -97,27 +273,15 @@ func (r *AWSLoadBalancerControllerReconciler) tagSubnets(ctx context.Context, co
 	case albo.ManualSubnetTaggingPolicy:
 		// if the tagging policy was changed to Manual then remove tags from previously tagged subnets
 		if tagged.Len() > 0 {
-			// when values are not specified with the tag name the tag value is not considered during tag removal
-			_, err = r.EC2Client.DeleteTags(ctx, &ec2.DeleteTagsInput{
-				Resources: sets.List(tagged),
-				Tags: []ec2types.Tag{
-					{
-						Key: aws.String(publicELBTagKey),
-					},
-					{
-						Key: aws.String(tagKeyALBOTagged),
-					},
-				},
-			})
-			if err != nil {
-				err = fmt.Errorf("failed to remove tags from currently tagged subnets %v: %w", taggedSubnets, err)
+			if err = r.removeOperatorTags(ctx, sets.List(tagged)); err != nil {
 				return
 			}
 		}
 		// the previously tagged subnets are now untagged
 		untagged = untagged.Union(tagged)
-		// removed the subnets which were untagged from the public subnets
+		// removed the subnets which were untagged from the public and internal subnets
 		public = public.Difference(tagged)
+		internal = internal.Difference(tagged)
 		// set the tagged subnets to empty
 		tagged = sets.New[string]()
 	default:
@@ -128,38 +292,497 @@ func (r *AWSLoadBalancerControllerReconciler) tagSubnets(ctx context.Context, co
 	taggedSubnets = sets.List(tagged)
 	publicSubnets = sets.List(public)
 	internalSubnets = sets.List(internal)
+	conflictingSubnets = sets.List(conflicting)
+	if err != nil {
+		return
+	}
+
+	var zoneTypes map[string]albo.SubnetZoneType
+	zoneTypes, err = r.describeZoneTypes(ctx, subnets)
+	if err != nil {
+		err = fmt.Errorf("failed to describe zones of subnets: %w", err)
+		return
+	}
+	subnetDetails = buildSubnetDetails(subnets, internal, public, tagged, conflicting, reasons, zoneTypes)
 	return
 }
 
-func classifySubnets(subnets []ec2types.Subnet) (sets.Set[string], sets.Set[string], sets.Set[string], sets.Set[string], error) {
-	var (
-		internal = sets.New[string]()
-		public   = sets.New[string]()
-		untagged = sets.New[string]()
-		tagged   = sets.New[string]()
-	)
+// describeZoneTypes returns the types of the zones where the given subnets are located.
+func (r *AWSLoadBalancerControllerReconciler) describeZoneTypes(ctx context.Context, subnets []ec2types.Subnet) (map[string]albo.SubnetZoneType, error) {
+	zoneNames := sets.New[string]()
+	for _, s := range subnets {
+		if zoneName := aws.ToString(s.AvailabilityZone); zoneName != "" {
+			zoneNames.Insert(zoneName)
+		}
+	}
+	zoneTypes := make(map[string]albo.SubnetZoneType, zoneNames.Len())
+	if zoneNames.Len() == 0 {
+		return zoneTypes, nil
+	}
+
+	response, err := r.EC2Client.DescribeAvailabilityZones(ctx, &ec2.DescribeAvailabilityZonesInput{
+		ZoneNames: sets.List(zoneNames),
+		// the local and wavelength zones are not listed unless all the zones are requested
+		AllAvailabilityZones: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe availability zones %v: %w", sets.List(zoneNames), err)
+	}
+	for _, zone := range response.AvailabilityZones {
+		var zoneType albo.SubnetZoneType
+		switch aws.ToString(zone.ZoneType) {
+		case availabilityZoneType:
+			zoneType = albo.AvailabilityZoneSubnetZoneType
+		case localZoneType:
+			zoneType = albo.LocalZoneSubnetZoneType
+		case wavelengthZoneType:
+			zoneType = albo.WavelengthZoneSubnetZoneType
+		default:
+			continue
+		}
+		zoneTypes[aws.ToString(zone.ZoneName)] = zoneType
+	}
+	return zoneTypes, nil
+}
+
+// createRoleTags adds the given role tag along with the operator's tag to the given subnets.
+func (r *AWSLoadBalancerControllerReconciler) createRoleTags(ctx context.Context, subnetIDs []string, roleTagKey string) error {
+	_, err := r.EC2Client.CreateTags(ctx, &ec2.CreateTagsInput{
+		Resources: subnetIDs,
+		Tags: []ec2types.Tag{
+			{
+				Key:   aws.String(roleTagKey),
+				Value: aws.String("1"),
+			},
+			{
+				Key:   aws.String(tagKeyALBOTagged),
+				Value: aws.String("1"),
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to tag subnets %v with %s: %w", subnetIDs, roleTagKey, err)
+	}
+	return nil
+}
+
+// removeOperatorTags removes the role tags along with the operator's tag from the given subnets.
+func (r *AWSLoadBalancerControllerReconciler) removeOperatorTags(ctx context.Context, subnetIDs []string) error {
+	// when values are not specified with the tag name the tag value is not considered during tag removal
+	_, err := r.EC2Client.DeleteTags(ctx, &ec2.DeleteTagsInput{
+		Resources: subnetIDs,
+		Tags: []ec2types.Tag{
+			{
+				Key: aws.String(publicELBTagKey),
+			},
+			{
+				Key: aws.String(internalELBTagKey),
+			},
+			{
+				Key: aws.String(tagKeyALBOTagged),
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove tags from currently tagged subnets %v: %w", subnetIDs, err)
+	}
+	return nil
+}
+
+// describeSubnetsByID returns the existing subnets among the given ones.
+func (r *AWSLoadBalancerControllerReconciler) describeSubnetsByID(ctx context.Context, ids []string) ([]ec2types.Subnet, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var subnets []ec2types.Subnet
+	// the subnets which don't exist anymore are ignored by the filter
+	subnetsPaginator := ec2.NewDescribeSubnetsPaginator(r.EC2Client, &ec2.DescribeSubnetsInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   aws.String(subnetIDFilterName),
+				Values: ids,
+			},
+		},
+	})
+	for subnetsPaginator.HasMorePages() {
+		response, err := subnetsPaginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list subnets %v: %w", ids, err)
+		}
+		subnets = append(subnets, response.Subnets...)
+	}
+	return subnets, nil
+}
+
+// describeClusterSubnets returns the subnets which are tagged as owned by the cluster.
+func (r *AWSLoadBalancerControllerReconciler) describeClusterSubnets(ctx context.Context) ([]ec2types.Subnet, error) {
+	subnetsPaginator := ec2.NewDescribeSubnetsPaginator(r.EC2Client, &ec2.DescribeSubnetsInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   aws.String(tagKeyFilterName),
+				Values: []string{fmt.Sprintf(clusterOwnedTagKey, r.ClusterName)},
+			},
+		},
+	})
+
+	var subnets []ec2types.Subnet
+	for subnetsPaginator.HasMorePages() {
+		response, err := subnetsPaginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list subnets for cluster id %s: %w", r.ClusterName, err)
+		}
+		subnets = append(subnets, response.Subnets...)
+	}
+	return subnets, nil
+}
+
+// resolveConflictingSubnets removes the role tag put by the operator from the conflicting subnets
+// which have the operator's tag, the role tag put by the user is kept.
+// The role tag put by the operator is the one the operator would put on the subnet:
+// the role of the selector which selects the subnet or the role detected from the route tables
+// when the subnets are not explicitly selected. The resolved subnets are moved from the conflicting set
+// to the set of the role kept on them.
+func (r *AWSLoadBalancerControllerReconciler) resolveConflictingSubnets(ctx context.Context, controller *albo.AWSLoadBalancerController, subnets []ec2types.Subnet, selectedPublic, selectedInternal, internal, public, conflicting sets.Set[string], reasons map[string]string) error {
+	operatorTagged := sets.New[string]()
+	for _, s := range subnets {
+		if subnetID := aws.ToString(s.SubnetId); conflicting.Has(subnetID) && hasTag(s.Tags, tagKeyALBOTagged) {
+			operatorTagged.Insert(subnetID)
+		}
+	}
+	if operatorTagged.Len() == 0 {
+		return nil
+	}
+
+	operatorRoleTagKeys := map[string]string{}
+	if controller.Spec.Subnets != nil {
+		for _, id := range sets.List(operatorTagged) {
+			switch {
+			case selectedPublic.Has(id):
+				operatorRoleTagKeys[id] = publicELBTagKey
+			case selectedInternal.Has(id):
+				operatorRoleTagKeys[id] = internalELBTagKey
+			}
+		}
+	} else {
+		roles, err := r.discoverSubnetRoles(ctx, sets.List(operatorTagged))
+		if err != nil {
+			return fmt.Errorf("failed to discover roles of subnets %v: %w", sets.List(operatorTagged), err)
+		}
+		for id, role := range roles {
+			operatorRoleTagKeys[id] = role.tagKey
+		}
+	}
+
+	for _, roles := range []struct {
+		operatorTagKey string
+		userTagKey     string
+		userRoleSet    sets.Set[string]
+	}{
+		{operatorTagKey: publicELBTagKey, userTagKey: internalELBTagKey, userRoleSet: internal},
+		{operatorTagKey: internalELBTagKey, userTagKey: publicELBTagKey, userRoleSet: public},
+	} {
+		var ids []string
+		for _, id := range sets.List(operatorTagged) {
+			if operatorRoleTagKeys[id] == roles.operatorTagKey {
+				ids = append(ids, id)
+			}
+		}
+		if len(ids) == 0 {
+			continue
+		}
+		if err := r.removeOperatorRoleTag(ctx, ids, roles.operatorTagKey); err != nil {
+			return err
+		}
+		conflicting.Delete(ids...)
+		roles.userRoleSet.Insert(ids...)
+		for _, id := range ids {
+			reasons[id] = fmt.Sprintf("the operator removed its tag %s from the subnet, tag %s put by the user was kept", roles.operatorTagKey, roles.userTagKey)
+		}
+	}
+	return nil
+}
+
+// removeOperatorRoleTag removes the given role tag along with the operator's tag from the given subnets.
+func (r *AWSLoadBalancerControllerReconciler) removeOperatorRoleTag(ctx context.Context, subnetIDs []string, roleTagKey string) error {
+	_, err := r.EC2Client.DeleteTags(ctx, &ec2.DeleteTagsInput{
+		Resources: subnetIDs,
+		Tags: []ec2types.Tag{
+			{
+				Key: aws.String(roleTagKey),
+			},
+			{
+				Key: aws.String(tagKeyALBOTagged),
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove tags %s and %s from subnets %v: %w", roleTagKey, tagKeyALBOTagged, subnetIDs, err)
+	}
+	return nil
+}
+
+// deleteRoleTag removes the given role tag from the given subnets.
+// The operator's tag is kept as the subnets are expected to be tagged with the other role.
+func (r *AWSLoadBalancerControllerReconciler) deleteRoleTag(ctx context.Context, subnetIDs []string, roleTagKey string) error {
+	_, err := r.EC2Client.DeleteTags(ctx, &ec2.DeleteTagsInput{
+		Resources: subnetIDs,
+		Tags: []ec2types.Tag{
+			{
+				Key: aws.String(roleTagKey),
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove tag %s from subnets %v: %w", roleTagKey, subnetIDs, err)
+	}
+	return nil
+}
+
+// subnetRole is the role tag detected for a subnet along with the reason of the detection.
+type subnetRole struct {
+	tagKey string
+	reason string
+}
+
+// discoverSubnetRoles looks up the route tables and the internet gateways of the cluster VPC
+// and detects the role of each of the given subnets.
+func (r *AWSLoadBalancerControllerReconciler) discoverSubnetRoles(ctx context.Context, subnetIDs []string) (map[string]subnetRole, error) {
+	var routeTables []ec2types.RouteTable
+	routeTablesPaginator := ec2.NewDescribeRouteTablesPaginator(r.EC2Client, &ec2.DescribeRouteTablesInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   aws.String(vpcIDFilterName),
+				Values: []string{r.VPCID},
+			},
+		},
+	})
+	for routeTablesPaginator.HasMorePages() {
+		response, err := routeTablesPaginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list route tables of vpc %s: %w", r.VPCID, err)
+		}
+		routeTables = append(routeTables, response.RouteTables...)
+	}
+
+	internetGateways := sets.New[string]()
+	gatewaysPaginator := ec2.NewDescribeInternetGatewaysPaginator(r.EC2Client, &ec2.DescribeInternetGatewaysInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   aws.String(attachmentVPCIDFilterName),
+				Values: []string{r.VPCID},
+			},
+		},
+	})
+	for gatewaysPaginator.HasMorePages() {
+		response, err := gatewaysPaginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list internet gateways of vpc %s: %w", r.VPCID, err)
+		}
+		for _, igw := range response.InternetGateways {
+			internetGateways.Insert(aws.ToString(igw.InternetGatewayId))
+		}
+	}
+
+	roles := make(map[string]subnetRole, len(subnetIDs))
+	for _, id := range subnetIDs {
+		roles[id] = detectSubnetRole(id, routeTables, internetGateways)
+	}
+	return roles, nil
+}
+
+// detectSubnetRole returns the role of the subnet based on the default route of its route table.
+// The subnet is public if it routes to an internet gateway attached to the VPC.
+// Otherwise (routing through a NAT gateway or any other target, or no default route at all) the subnet is internal.
+// The subnets without an explicit route table association use the main route table of the VPC.
+func detectSubnetRole(subnetID string, routeTables []ec2types.RouteTable, internetGateways sets.Set[string]) subnetRole {
+	routeTable := findSubnetRouteTable(subnetID, routeTables)
+	if routeTable == nil {
+		return subnetRole{
+			tagKey: internalELBTagKey,
+			reason: "no route table is associated with the subnet",
+		}
+	}
+	routeTableID := aws.ToString(routeTable.RouteTableId)
 
+	var natRoute, otherRoute *ec2types.Route
+	for i, route := range routeTable.Routes {
+		destination := defaultRouteDestination(route)
+		if destination == "" || route.State == ec2types.RouteStateBlackhole {
+			continue
+		}
+		if gatewayID := aws.ToString(route.GatewayId); internetGateways.Has(gatewayID) {
+			return subnetRole{
+				tagKey: publicELBTagKey,
+				reason: fmt.Sprintf("route table %s routes %s to internet gateway %s", routeTableID, destination, gatewayID),
+			}
+		}
+		if route.NatGatewayId != nil {
+			natRoute = &routeTable.Routes[i]
+		} else if otherRoute == nil {
+			otherRoute = &routeTable.Routes[i]
+		}
+	}
+
+	switch {
+	case natRoute != nil:
+		return subnetRole{
+			tagKey: internalELBTagKey,
+			reason: fmt.Sprintf("route table %s routes %s through NAT gateway %s", routeTableID, defaultRouteDestination(*natRoute), aws.ToString(natRoute.NatGatewayId)),
+		}
+	case otherRoute != nil:
+		return subnetRole{
+			tagKey: internalELBTagKey,
+			reason: fmt.Sprintf("route table %s routes %s to a target which is not an internet gateway", routeTableID, defaultRouteDestination(*otherRoute)),
+		}
+	}
+	return subnetRole{
+		tagKey: internalELBTagKey,
+		reason: fmt.Sprintf("route table %s has no default route", routeTableID),
+	}
+}
+
+// findSubnetRouteTable returns the route table explicitly associated with the subnet
+// or the main route table if there is no explicit association.
+func findSubnetRouteTable(subnetID string, routeTables []ec2types.RouteTable) *ec2types.RouteTable {
+	var main *ec2types.RouteTable
+	for i, rt := range routeTables {
+		for _, assoc := range rt.Associations {
+			if aws.ToString(assoc.SubnetId) == subnetID {
+				return &routeTables[i]
+			}
+			if aws.ToBool(assoc.Main) {
+				main = &routeTables[i]
+			}
+		}
+	}
+	return main
+}
+
+// defaultRouteDestination returns the destination of the route if it's a default (IPv4 or IPv6) route.
+// An empty string is returned otherwise.
+func defaultRouteDestination(route ec2types.Route) string {
+	if aws.ToString(route.DestinationCidrBlock) == ipv4DefaultRouteDestination {
+		return ipv4DefaultRouteDestination
+	}
+	if aws.ToString(route.DestinationIpv6CidrBlock) == ipv6DefaultRouteDestination {
+		return ipv6DefaultRouteDestination
+	}
+	return ""
+}
+
+// buildSubnetDetails returns the status details of the given subnets.
+// The reasons of the subnets tagged by the operator during this reconciliation are taken from the given map.
+// The subnets located on an Outpost get the Outpost zone type, the zone type of the other subnets
+// is taken from the given map.
+func buildSubnetDetails(subnets []ec2types.Subnet, internal, public, tagged, conflicting sets.Set[string], reasons map[string]string, zoneTypes map[string]albo.SubnetZoneType) []albo.AWSLoadBalancerControllerSubnetDetails {
+	details := make([]albo.AWSLoadBalancerControllerSubnetDetails, 0, len(subnets))
 	for _, s := range subnets {
 		subnetID := aws.ToString(s.SubnetId)
-		if hasTag(s.Tags, internalELBTagKey) {
-			internal.Insert(subnetID)
+		detail := albo.AWSLoadBalancerControllerSubnetDetails{
+			ID:                      subnetID,
+			AvailabilityZone:        aws.ToString(s.AvailabilityZone),
+			ZoneType:                zoneTypes[aws.ToString(s.AvailabilityZone)],
+			IPv4CIDR:                aws.ToString(s.CidrBlock),
+			AvailableIPAddressCount: aws.ToInt32(s.AvailableIpAddressCount),
+		}
+		if s.OutpostArn != nil {
+			detail.ZoneType = albo.OutpostSubnetZoneType
 		}
-		if hasTag(s.Tags, publicELBTagKey) {
-			if internal.Has(subnetID) {
-				return nil, nil, nil, nil, fmt.Errorf("subnet %s has both tags with keys %s and %s", subnetID, internalELBTagKey, publicELBTagKey)
+		for _, assoc := range s.Ipv6CidrBlockAssociationSet {
+			if assoc.Ipv6CidrBlockState != nil && assoc.Ipv6CidrBlockState.State == ec2types.SubnetCidrBlockStateCodeAssociated {
+				detail.IPv6CIDRs = append(detail.IPv6CIDRs, aws.ToString(assoc.Ipv6CidrBlock))
 			}
-			public.Insert(subnetID)
-			// only check operator tagging if the subnet is a public subnet
-			if hasTag(s.Tags, tagKeyALBOTagged) {
-				tagged.Insert(subnetID)
+		}
+		var roleTagKey string
+		switch {
+		case public.Has(subnetID):
+			detail.Role = albo.PublicSubnetRole
+			roleTagKey = publicELBTagKey
+		case internal.Has(subnetID):
+			detail.Role = albo.InternalSubnetRole
+			roleTagKey = internalELBTagKey
+		}
+		if roleTagKey != "" {
+			detail.RoleSource = albo.UserSubnetRoleSource
+			if tagged.Has(subnetID) {
+				detail.RoleSource = albo.OperatorSubnetRoleSource
+			}
+		}
+		switch {
+		case reasons[subnetID] != "":
+			detail.Reason = reasons[subnetID]
+		case conflicting.Has(subnetID):
+			detail.Reason = fmt.Sprintf("subnet has both tags %s and %s", internalELBTagKey, publicELBTagKey)
+		case roleTagKey == "":
+			detail.Reason = "subnet has no role tag"
+		case tagged.Has(subnetID):
+			detail.Reason = fmt.Sprintf("subnet was tagged with %s by the operator", roleTagKey)
+		default:
+			detail.Reason = fmt.Sprintf("subnet was tagged with %s by the user", roleTagKey)
+		}
+		details = append(details, detail)
+	}
+	sort.Slice(details, func(i, j int) bool { return details[i].ID < details[j].ID })
+	return details
+}
+
+// mergeSubnets returns the given subnets without duplicates.
+func mergeSubnets(subnets []ec2types.Subnet, others ...[]ec2types.Subnet) []ec2types.Subnet {
+	seen := sets.New[string]()
+	var merged []ec2types.Subnet
+	for _, list := range append([][]ec2types.Subnet{subnets}, others...) {
+		for _, s := range list {
+			if subnetID := aws.ToString(s.SubnetId); !seen.Has(subnetID) {
+				seen.Insert(subnetID)
+				merged = append(merged, s)
 			}
 		}
-		if !internal.Has(subnetID) && !public.Has(subnetID) {
+	}
+	return merged
+}
+
+// subnetIDs returns the set of the IDs of the given subnets.
+func subnetIDs(subnets []ec2types.Subnet) sets.Set[string] {
+	ids := sets.New[string]()
+	for _, s := range subnets {
+		ids.Insert(aws.ToString(s.SubnetId))
+	}
+	return ids
+}
+
+// classifySubnets returns the internal, public, tagged, untagged and conflicting subnets.
+// The conflicting subnets have both role tags, they don't belong to any other set.
+func classifySubnets(subnets []ec2types.Subnet) (sets.Set[string], sets.Set[string], sets.Set[string], sets.Set[string], sets.Set[string]) {
+	var (
+		internal    = sets.New[string]()
+		public      = sets.New[string]()
+		untagged    = sets.New[string]()
+		tagged      = sets.New[string]()
+		conflicting = sets.New[string]()
+	)
+
+	for _, s := range subnets {
+		subnetID := aws.ToString(s.SubnetId)
+		isInternal, isPublic := hasTag(s.Tags, internalELBTagKey), hasTag(s.Tags, publicELBTagKey)
+		switch {
+		case isInternal && isPublic:
+			conflicting.Insert(subnetID)
+			continue
+		case isInternal:
+			internal.Insert(subnetID)
+		case isPublic:
+			public.Insert(subnetID)
+		default:
 			untagged.Insert(subnetID)
+			continue
+		}
+		// the operator tags both public and internal subnets
+		if hasTag(s.Tags, tagKeyALBOTagged) {
+			tagged.Insert(subnetID)
 		}
 	}
 
-	return internal, public, tagged, untagged, nil
+	return internal, public, tagged, untagged, conflicting
 }
 
 func hasTag(tags []ec2types.Tag, key string) bool {
@@ -6,13 +6,19 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 
 	awstypes "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	albo "github.com/openshift/aws-load-balancer-operator/api/v1"
@@ -29,7 +35,7 @@ func TestClassifySubnet(t *testing.T) {
 		expectedInternalSubnets []string
 		expectedUntaggedSubnets []string
 		expectedTaggedSubnets   []string
-		expectedError           string
+		expectedConflicting     []string
 	}{
 		{
 			name: "mixed subnets",
@@ -51,7 +57,8 @@ func TestClassifySubnet(t *testing.T) {
 				testSubnet("subnet-1", publicELBTagKey),
 				testSubnet("subnet-2", publicELBTagKey, internalELBTagKey),
 			},
-			expectedError: "subnet subnet-2 has both tags with keys kubernetes.io/role/internal-elb and kubernetes.io/role/elb",
+			expectedPublicSubnets: []string{"subnet-1"},
+			expectedConflicting:   []string{"subnet-2"},
 		},
 		{
 			name: "tagged subnets",
@@ -66,28 +73,25 @@ func TestClassifySubnet(t *testing.T) {
 			expectedUntaggedSubnets: []string{"subnet-3"},
 		},
 		{
-			name: "ignore internal subnets with ALBO tag",
+			name: "internal subnets with ALBO tag",
 			inputSubnets: []ec2types.Subnet{
 				testSubnet("subnet-1", publicELBTagKey, tagKeyALBOTagged),
 				testSubnet("subnet-2", internalELBTagKey, tagKeyALBOTagged),
 			},
 			expectedInternalSubnets: []string{"subnet-2"},
 			expectedPublicSubnets:   []string{"subnet-1"},
-			expectedTaggedSubnets:   []string{"subnet-1"},
+			expectedTaggedSubnets:   []string{"subnet-1", "subnet-2"},
+		},
+		{
+			name: "ignore untagged subnets with ALBO tag",
+			inputSubnets: []ec2types.Subnet{
+				testSubnet("subnet-1", tagKeyALBOTagged),
+			},
+			expectedUntaggedSubnets: []string{"subnet-1"},
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
-			internal, public, tagged, untagged, err := classifySubnets(tc.inputSubnets)
-			if tc.expectedError != "" {
-				if err == nil {
-					t.Errorf("expected error, got nil")
-					return
-				}
-				if !strings.Contains(err.Error(), tc.expectedError) {
-					t.Errorf("expected error %s, instead got %s", tc.expectedError, err.Error())
-					return
-				}
-			}
+			internal, public, tagged, untagged, conflicting := classifySubnets(tc.inputSubnets)
 			if !internal.Equal(sets.New[string](tc.expectedInternalSubnets...)) {
 				t.Errorf("expected internal subnets %v, got %v", tc.expectedInternalSubnets, sets.List(internal))
 			}
@@ -100,6 +104,9 @@ func TestClassifySubnet(t *testing.T) {
 			if !tagged.Equal(sets.New[string](tc.expectedTaggedSubnets...)) {
 				t.Errorf("expected tagged subnets %v, got %v", tc.expectedTaggedSubnets, sets.List(tagged))
 			}
+			if !conflicting.Equal(sets.New[string](tc.expectedConflicting...)) {
+				t.Errorf("expected conflicting subnets %v, got %v", tc.expectedConflicting, sets.List(conflicting))
+			}
 		})
 	}
 }
@@ -119,16 +126,28 @@ func testSubnet(name string, tagKeys ...string) ec2types.Subnet {
 
 func TestTagSubnets(t *testing.T) {
 	for _, tc := range []struct {
-		name                        string
-		currentSubnets              []ec2types.Subnet
-		statusUntaggedSubnets       []string
-		expectedTaggedSubnets       []string
-		expectedUntaggedSubnets     []string
-		taggingPolicy               albo.SubnetTaggingPolicy
-		expectedPublicSubnets       []string
-		expectedInternalSubnets     []string
-		expectedCreateTagOperations []string
-		expectedRemoveTagOperations []string
+		name                                string
+		currentSubnets                      []ec2types.Subnet
+		routeTables                         []ec2types.RouteTable
+		internetGateways                    []string
+		statusUntaggedSubnets               []string
+		expectedTaggedSubnets               []string
+		expectedUntaggedSubnets             []string
+		taggingPolicy                       albo.SubnetTaggingPolicy
+		expectedPublicSubnets               []string
+		expectedInternalSubnets             []string
+		expectedCreateTagOperations         []string
+		expectedCreateInternalTagOperations []string
+		expectedRemoveTagOperations         []string
+		expectedRemoveRoleTagOperations     []string
+		expectedResolveConflictOperations   []string
+		expectedConflictingSubnets          []string
+		conflictPolicy                      albo.SubnetConflictPolicy
+		selection                           *albo.AWSLoadBalancerControllerSubnets
+		accountSubnets                      []ec2types.Subnet
+		zoneTypes                           map[string]string
+		expectedSubnetDetails               []albo.AWSLoadBalancerControllerSubnetDetails
+		expectedSelectionConflicts          []string
 	}{
 		{
 			name: "auto tagging, no preexisting tagged subnets",
@@ -137,11 +156,147 @@ func TestTagSubnets(t *testing.T) {
 				testSubnet("subnet-2", internalELBTagKey),
 				testSubnet("subnet-3", publicELBTagKey),
 			},
+			routeTables: []ec2types.RouteTable{
+				testRouteTable("rtb-main", true, nil, testIGWRoute("igw-1")),
+			},
+			internetGateways:            []string{"igw-1"},
 			taggingPolicy:               albo.AutoSubnetTaggingPolicy,
 			expectedTaggedSubnets:       []string{"subnet-1"},
 			expectedPublicSubnets:       []string{"subnet-1", "subnet-3"},
 			expectedInternalSubnets:     []string{"subnet-2"},
 			expectedCreateTagOperations: []string{"subnet-1"},
+			expectedSubnetDetails: []albo.AWSLoadBalancerControllerSubnetDetails{
+				{
+					ID:         "subnet-1",
+					Role:       albo.PublicSubnetRole,
+					Reason:     "route table rtb-main routes 0.0.0.0/0 to internet gateway igw-1",
+					RoleSource: albo.OperatorSubnetRoleSource,
+				},
+				{
+					ID:         "subnet-2",
+					Role:       albo.InternalSubnetRole,
+					Reason:     "subnet was tagged with kubernetes.io/role/internal-elb by the user",
+					RoleSource: albo.UserSubnetRoleSource,
+				},
+				{
+					ID:         "subnet-3",
+					Role:       albo.PublicSubnetRole,
+					Reason:     "subnet was tagged with kubernetes.io/role/elb by the user",
+					RoleSource: albo.UserSubnetRoleSource,
+				},
+			},
+		},
+		{
+			name: "auto tagging, private subnets detected from route tables",
+			currentSubnets: []ec2types.Subnet{
+				testSubnet("subnet-1"),
+				testSubnet("subnet-2"),
+				testSubnet("subnet-3"),
+				testSubnet("subnet-4"),
+				testSubnet("subnet-5"),
+			},
+			routeTables: []ec2types.RouteTable{
+				testRouteTable("rtb-main", true, nil, testIGWRoute("igw-1")),
+				testRouteTable("rtb-nat", false, []string{"subnet-2"}, ec2types.Route{
+					DestinationCidrBlock: awstypes.String("0.0.0.0/0"),
+					NatGatewayId:         awstypes.String("nat-1"),
+				}),
+				testRouteTable("rtb-local", false, []string{"subnet-3"}, ec2types.Route{
+					DestinationCidrBlock: awstypes.String("10.0.0.0/16"),
+					GatewayId:            awstypes.String("local"),
+				}),
+				testRouteTable("rtb-tgw", false, []string{"subnet-4"}, ec2types.Route{
+					DestinationCidrBlock: awstypes.String("0.0.0.0/0"),
+					TransitGatewayId:     awstypes.String("tgw-1"),
+				}),
+				testRouteTable("rtb-blackhole", false, []string{"subnet-5"}, ec2types.Route{
+					DestinationCidrBlock: awstypes.String("0.0.0.0/0"),
+					GatewayId:            awstypes.String("igw-1"),
+					State:                ec2types.RouteStateBlackhole,
+				}),
+			},
+			internetGateways:                    []string{"igw-1"},
+			taggingPolicy:                       albo.AutoSubnetTaggingPolicy,
+			expectedTaggedSubnets:               []string{"subnet-1", "subnet-2", "subnet-3", "subnet-4", "subnet-5"},
+			expectedPublicSubnets:               []string{"subnet-1"},
+			expectedInternalSubnets:             []string{"subnet-2", "subnet-3", "subnet-4", "subnet-5"},
+			expectedCreateTagOperations:         []string{"subnet-1"},
+			expectedCreateInternalTagOperations: []string{"subnet-2", "subnet-3", "subnet-4", "subnet-5"},
+			expectedSubnetDetails: []albo.AWSLoadBalancerControllerSubnetDetails{
+				{
+					ID:         "subnet-1",
+					Role:       albo.PublicSubnetRole,
+					Reason:     "route table rtb-main routes 0.0.0.0/0 to internet gateway igw-1",
+					RoleSource: albo.OperatorSubnetRoleSource,
+				},
+				{
+					ID:         "subnet-2",
+					Role:       albo.InternalSubnetRole,
+					Reason:     "route table rtb-nat routes 0.0.0.0/0 through NAT gateway nat-1",
+					RoleSource: albo.OperatorSubnetRoleSource,
+				},
+				{
+					ID:         "subnet-3",
+					Role:       albo.InternalSubnetRole,
+					Reason:     "route table rtb-local has no default route",
+					RoleSource: albo.OperatorSubnetRoleSource,
+				},
+				{
+					ID:         "subnet-4",
+					Role:       albo.InternalSubnetRole,
+					Reason:     "route table rtb-tgw routes 0.0.0.0/0 to a target which is not an internet gateway",
+					RoleSource: albo.OperatorSubnetRoleSource,
+				},
+				{
+					ID:         "subnet-5",
+					Role:       albo.InternalSubnetRole,
+					Reason:     "route table rtb-blackhole has no default route",
+					RoleSource: albo.OperatorSubnetRoleSource,
+				},
+			},
+		},
+		{
+			name: "auto tagging, gateway not attached to the vpc",
+			currentSubnets: []ec2types.Subnet{
+				testSubnet("subnet-1"),
+			},
+			routeTables: []ec2types.RouteTable{
+				testRouteTable("rtb-1", false, []string{"subnet-1"}, ec2types.Route{
+					DestinationIpv6CidrBlock: awstypes.String("::/0"),
+					GatewayId:                awstypes.String("vgw-1"),
+				}),
+			},
+			internetGateways:                    []string{"igw-1"},
+			taggingPolicy:                       albo.AutoSubnetTaggingPolicy,
+			expectedTaggedSubnets:               []string{"subnet-1"},
+			expectedInternalSubnets:             []string{"subnet-1"},
+			expectedCreateInternalTagOperations: []string{"subnet-1"},
+			expectedSubnetDetails: []albo.AWSLoadBalancerControllerSubnetDetails{
+				{
+					ID:         "subnet-1",
+					Role:       albo.InternalSubnetRole,
+					Reason:     "route table rtb-1 routes ::/0 to a target which is not an internet gateway",
+					RoleSource: albo.OperatorSubnetRoleSource,
+				},
+			},
+		},
+		{
+			name: "auto tagging, no route table",
+			currentSubnets: []ec2types.Subnet{
+				testSubnet("subnet-1"),
+			},
+			taggingPolicy:                       albo.AutoSubnetTaggingPolicy,
+			expectedTaggedSubnets:               []string{"subnet-1"},
+			expectedInternalSubnets:             []string{"subnet-1"},
+			expectedCreateInternalTagOperations: []string{"subnet-1"},
+			expectedSubnetDetails: []albo.AWSLoadBalancerControllerSubnetDetails{
+				{
+					ID:         "subnet-1",
+					Role:       albo.InternalSubnetRole,
+					Reason:     "no route table is associated with the subnet",
+					RoleSource: albo.OperatorSubnetRoleSource,
+				},
+			},
 		},
 		{
 			name: "auto tagging, with preexisting tagged subnets",
@@ -150,11 +305,352 @@ func TestTagSubnets(t *testing.T) {
 				testSubnet("subnet-2", internalELBTagKey),
 				testSubnet("subnet-3", publicELBTagKey),
 			},
+			routeTables: []ec2types.RouteTable{
+				testRouteTable("rtb-main", true, nil, testIGWRoute("igw-1")),
+			},
+			internetGateways:        []string{"igw-1"},
 			taggingPolicy:           albo.AutoSubnetTaggingPolicy,
 			expectedTaggedSubnets:   []string{"subnet-1"},
 			expectedPublicSubnets:   []string{"subnet-1", "subnet-3"},
 			expectedInternalSubnets: []string{"subnet-2"},
 		},
+		{
+			name: "auto tagging, preexisting tagged subnets changed their roles",
+			currentSubnets: []ec2types.Subnet{
+				testSubnet("subnet-1", publicELBTagKey, tagKeyALBOTagged),
+				testSubnet("subnet-2", internalELBTagKey, tagKeyALBOTagged),
+				testSubnet("subnet-3", publicELBTagKey),
+				testSubnet("subnet-4", internalELBTagKey, tagKeyALBOTagged),
+			},
+			routeTables: []ec2types.RouteTable{
+				testRouteTable("rtb-main", true, nil, ec2types.Route{
+					DestinationCidrBlock: awstypes.String("0.0.0.0/0"),
+					NatGatewayId:         awstypes.String("nat-1"),
+				}),
+				testRouteTable("rtb-public", false, []string{"subnet-2"}, testIGWRoute("igw-1")),
+			},
+			internetGateways:                    []string{"igw-1"},
+			taggingPolicy:                       albo.AutoSubnetTaggingPolicy,
+			expectedTaggedSubnets:               []string{"subnet-1", "subnet-2", "subnet-4"},
+			expectedPublicSubnets:               []string{"subnet-2", "subnet-3"},
+			expectedInternalSubnets:             []string{"subnet-1", "subnet-4"},
+			expectedCreateTagOperations:         []string{"subnet-2"},
+			expectedCreateInternalTagOperations: []string{"subnet-1"},
+			expectedRemoveRoleTagOperations:     []string{"subnet-1", "subnet-2"},
+			expectedSubnetDetails: []albo.AWSLoadBalancerControllerSubnetDetails{
+				{
+					ID:         "subnet-1",
+					Role:       albo.InternalSubnetRole,
+					Reason:     "route table rtb-main routes 0.0.0.0/0 through NAT gateway nat-1",
+					RoleSource: albo.OperatorSubnetRoleSource,
+				},
+				{
+					ID:         "subnet-2",
+					Role:       albo.PublicSubnetRole,
+					Reason:     "route table rtb-public routes 0.0.0.0/0 to internet gateway igw-1",
+					RoleSource: albo.OperatorSubnetRoleSource,
+				},
+				{
+					ID:         "subnet-3",
+					Role:       albo.PublicSubnetRole,
+					Reason:     "subnet was tagged with kubernetes.io/role/elb by the user",
+					RoleSource: albo.UserSubnetRoleSource,
+				},
+				{
+					ID:         "subnet-4",
+					Role:       albo.InternalSubnetRole,
+					Reason:     "route table rtb-main routes 0.0.0.0/0 through NAT gateway nat-1",
+					RoleSource: albo.OperatorSubnetRoleSource,
+				},
+			},
+		},
+		{
+			name: "auto tagging, conflicting subnets are reported",
+			currentSubnets: []ec2types.Subnet{
+				testSubnet("subnet-1", publicELBTagKey, internalELBTagKey, tagKeyALBOTagged),
+				testSubnet("subnet-2", publicELBTagKey, internalELBTagKey),
+				testSubnet("subnet-3", publicELBTagKey),
+			},
+			taggingPolicy:              albo.AutoSubnetTaggingPolicy,
+			expectedPublicSubnets:      []string{"subnet-3"},
+			expectedConflictingSubnets: []string{"subnet-1", "subnet-2"},
+			expectedSubnetDetails: []albo.AWSLoadBalancerControllerSubnetDetails{
+				{
+					ID:     "subnet-1",
+					Reason: "subnet has both tags kubernetes.io/role/internal-elb and kubernetes.io/role/elb",
+				},
+				{
+					ID:     "subnet-2",
+					Reason: "subnet has both tags kubernetes.io/role/internal-elb and kubernetes.io/role/elb",
+				},
+				{
+					ID:         "subnet-3",
+					Role:       albo.PublicSubnetRole,
+					Reason:     "subnet was tagged with kubernetes.io/role/elb by the user",
+					RoleSource: albo.UserSubnetRoleSource,
+				},
+			},
+		},
+		{
+			name: "auto tagging, operator tags removed from conflicting subnets",
+			currentSubnets: []ec2types.Subnet{
+				testSubnet("subnet-1", publicELBTagKey, internalELBTagKey, tagKeyALBOTagged),
+				testSubnet("subnet-2", publicELBTagKey, internalELBTagKey, tagKeyALBOTagged),
+				testSubnet("subnet-3", publicELBTagKey, internalELBTagKey),
+			},
+			routeTables: []ec2types.RouteTable{
+				testRouteTable("rtb-main", true, nil, ec2types.Route{
+					DestinationCidrBlock: awstypes.String("0.0.0.0/0"),
+					NatGatewayId:         awstypes.String("nat-1"),
+				}),
+				testRouteTable("rtb-public", false, []string{"subnet-2"}, testIGWRoute("igw-1")),
+			},
+			internetGateways:                  []string{"igw-1"},
+			taggingPolicy:                     albo.AutoSubnetTaggingPolicy,
+			conflictPolicy:                    albo.RemoveOperatorTagSubnetConflictPolicy,
+			expectedPublicSubnets:             []string{"subnet-1"},
+			expectedInternalSubnets:           []string{"subnet-2"},
+			expectedConflictingSubnets:        []string{"subnet-3"},
+			expectedResolveConflictOperations: []string{"subnet-1", "subnet-2"},
+			expectedSubnetDetails: []albo.AWSLoadBalancerControllerSubnetDetails{
+				{
+					ID:         "subnet-1",
+					Role:       albo.PublicSubnetRole,
+					Reason:     "the operator removed its tag kubernetes.io/role/internal-elb from the subnet, tag kubernetes.io/role/elb put by the user was kept",
+					RoleSource: albo.UserSubnetRoleSource,
+				},
+				{
+					ID:         "subnet-2",
+					Role:       albo.InternalSubnetRole,
+					Reason:     "the operator removed its tag kubernetes.io/role/elb from the subnet, tag kubernetes.io/role/internal-elb put by the user was kept",
+					RoleSource: albo.UserSubnetRoleSource,
+				},
+				{
+					ID:     "subnet-3",
+					Reason: "subnet has both tags kubernetes.io/role/internal-elb and kubernetes.io/role/elb",
+				},
+			},
+		},
+		{
+			name: "auto tagging, subnets selected by ids",
+			currentSubnets: []ec2types.Subnet{
+				testSubnet("subnet-1"),
+				testSubnet("subnet-2"),
+			},
+			accountSubnets: []ec2types.Subnet{
+				testAccountSubnet("subnet-1", "test-vpc", "us-east-1a"),
+				testAccountSubnet("subnet-2", "test-vpc", "us-east-1a"),
+				testAccountSubnet("subnet-3", "test-vpc", "us-east-1b"),
+			},
+			selection: &albo.AWSLoadBalancerControllerSubnets{
+				Public:   &albo.SubnetSelector{IDs: []albo.SubnetID{"subnet-3"}},
+				Internal: &albo.SubnetSelector{IDs: []albo.SubnetID{"subnet-1"}},
+			},
+			taggingPolicy:                       albo.AutoSubnetTaggingPolicy,
+			expectedTaggedSubnets:               []string{"subnet-1", "subnet-3"},
+			expectedUntaggedSubnets:             []string{"subnet-2"},
+			expectedPublicSubnets:               []string{"subnet-3"},
+			expectedInternalSubnets:             []string{"subnet-1"},
+			expectedCreateTagOperations:         []string{"subnet-3"},
+			expectedCreateInternalTagOperations: []string{"subnet-1"},
+			expectedSubnetDetails: []albo.AWSLoadBalancerControllerSubnetDetails{
+				{
+					ID:         "subnet-1",
+					Role:       albo.InternalSubnetRole,
+					Reason:     "subnet is selected by spec.subnets.internal",
+					RoleSource: albo.OperatorSubnetRoleSource,
+				},
+				{
+					ID:     "subnet-2",
+					Reason: "subnet has no role tag",
+				},
+				{
+					ID:               "subnet-3",
+					Role:             albo.PublicSubnetRole,
+					Reason:           "subnet is selected by spec.subnets.public",
+					RoleSource:       albo.OperatorSubnetRoleSource,
+					AvailabilityZone: "us-east-1b",
+					ZoneType:         albo.AvailabilityZoneSubnetZoneType,
+				},
+			},
+		},
+		{
+			name: "auto tagging, unresolved selected subnets are reported",
+			currentSubnets: []ec2types.Subnet{
+				testSubnet("subnet-1", publicELBTagKey, tagKeyALBOTagged),
+				testSubnet("subnet-2"),
+			},
+			accountSubnets: []ec2types.Subnet{
+				testAccountSubnet("subnet-1", "test-vpc", "us-east-1a"),
+				testAccountSubnet("subnet-2", "test-vpc", "us-east-1a"),
+				testAccountSubnet("subnet-5", "other-vpc", "us-east-1b"),
+			},
+			selection: &albo.AWSLoadBalancerControllerSubnets{
+				Public:   &albo.SubnetSelector{IDs: []albo.SubnetID{"subnet-1", "subnet-5", "subnet-9"}},
+				Internal: &albo.SubnetSelector{AvailabilityZones: []string{"us-east-1c"}},
+			},
+			taggingPolicy:           albo.AutoSubnetTaggingPolicy,
+			expectedTaggedSubnets:   []string{"subnet-1"},
+			expectedUntaggedSubnets: []string{"subnet-2"},
+			expectedPublicSubnets:   []string{"subnet-1"},
+			expectedSelectionConflicts: []string{
+				"spec.subnets.public: subnet subnet-5 belongs to vpc other-vpc instead of the cluster vpc test-vpc",
+				"spec.subnets.public: subnet subnet-9 not found",
+				"spec.subnets.internal: no subnets match the selector",
+			},
+		},
+		{
+			name: "auto tagging, subnets selected by availability zones and tags",
+			currentSubnets: []ec2types.Subnet{
+				testSubnet("subnet-1", publicELBTagKey, tagKeyALBOTagged),
+				testSubnet("subnet-2", internalELBTagKey, tagKeyALBOTagged),
+				testSubnet("subnet-3", publicELBTagKey),
+			},
+			accountSubnets: []ec2types.Subnet{
+				testAccountSubnet("subnet-1", "test-vpc", "us-east-1a"),
+				testAccountSubnet("subnet-2", "test-vpc", "us-east-1b"),
+				testAccountSubnet("subnet-3", "test-vpc", "us-east-1a"),
+				testAccountSubnet("subnet-4", "test-vpc", "us-east-1c", ec2types.Tag{Key: awstypes.String("tier"), Value: awstypes.String("private")}),
+				testAccountSubnet("subnet-5", "other-vpc", "us-east-1b"),
+				testAccountSubnet("subnet-6", "test-vpc", "us-east-1c", ec2types.Tag{Key: awstypes.String("tier"), Value: awstypes.String("public")}),
+			},
+			selection: &albo.AWSLoadBalancerControllerSubnets{
+				Public: &albo.SubnetSelector{AvailabilityZones: []string{"us-east-1b"}},
+				Internal: &albo.SubnetSelector{Tags: []albo.SubnetTagSelector{
+					{Key: "tier", Value: "private"},
+				}},
+			},
+			taggingPolicy:                       albo.AutoSubnetTaggingPolicy,
+			expectedTaggedSubnets:               []string{"subnet-2", "subnet-4"},
+			expectedUntaggedSubnets:             []string{"subnet-1"},
+			expectedPublicSubnets:               []string{"subnet-2", "subnet-3"},
+			expectedInternalSubnets:             []string{"subnet-4"},
+			expectedCreateTagOperations:         []string{"subnet-2"},
+			expectedCreateInternalTagOperations: []string{"subnet-4"},
+			expectedRemoveTagOperations:         []string{"subnet-1"},
+			expectedRemoveRoleTagOperations:     []string{"subnet-2"},
+			expectedSubnetDetails: []albo.AWSLoadBalancerControllerSubnetDetails{
+				{
+					ID:     "subnet-1",
+					Reason: "subnet has no role tag",
+				},
+				{
+					ID:         "subnet-2",
+					Role:       albo.PublicSubnetRole,
+					Reason:     "subnet is selected by spec.subnets.public",
+					RoleSource: albo.OperatorSubnetRoleSource,
+				},
+				{
+					ID:         "subnet-3",
+					Role:       albo.PublicSubnetRole,
+					Reason:     "subnet was tagged with kubernetes.io/role/elb by the user",
+					RoleSource: albo.UserSubnetRoleSource,
+				},
+				{
+					ID:               "subnet-4",
+					Role:             albo.InternalSubnetRole,
+					Reason:           "subnet is selected by spec.subnets.internal",
+					RoleSource:       albo.OperatorSubnetRoleSource,
+					AvailabilityZone: "us-east-1c",
+					ZoneType:         albo.AvailabilityZoneSubnetZoneType,
+				},
+			},
+		},
+		{
+			name: "manual tagging, subnet location and addressing",
+			currentSubnets: []ec2types.Subnet{
+				{
+					SubnetId:                awstypes.String("subnet-1"),
+					AvailabilityZone:        awstypes.String("us-east-1a"),
+					CidrBlock:               awstypes.String("10.0.0.0/24"),
+					AvailableIpAddressCount: awstypes.Int32(250),
+					Ipv6CidrBlockAssociationSet: []ec2types.SubnetIpv6CidrBlockAssociation{
+						{
+							Ipv6CidrBlock:      awstypes.String("2600:1f18::/64"),
+							Ipv6CidrBlockState: &ec2types.SubnetCidrBlockState{State: ec2types.SubnetCidrBlockStateCodeAssociated},
+						},
+						{
+							Ipv6CidrBlock:      awstypes.String("2600:1f19::/64"),
+							Ipv6CidrBlockState: &ec2types.SubnetCidrBlockState{State: ec2types.SubnetCidrBlockStateCodeDisassociated},
+						},
+					},
+					Tags: []ec2types.Tag{{Key: awstypes.String(publicELBTagKey), Value: awstypes.String("1")}},
+				},
+				{
+					SubnetId:                awstypes.String("subnet-2"),
+					AvailabilityZone:        awstypes.String("us-east-1-bos-1a"),
+					CidrBlock:               awstypes.String("10.0.1.0/24"),
+					AvailableIpAddressCount: awstypes.Int32(0),
+				},
+				{
+					SubnetId:                awstypes.String("subnet-3"),
+					AvailabilityZone:        awstypes.String("us-east-1-wl1-bos-wlz-1"),
+					CidrBlock:               awstypes.String("10.0.2.0/24"),
+					AvailableIpAddressCount: awstypes.Int32(10),
+				},
+				{
+					SubnetId:                awstypes.String("subnet-4"),
+					AvailabilityZone:        awstypes.String("us-east-1a"),
+					OutpostArn:              awstypes.String("arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0"),
+					CidrBlock:               awstypes.String("10.0.3.0/24"),
+					AvailableIpAddressCount: awstypes.Int32(20),
+				},
+			},
+			zoneTypes: map[string]string{
+				"us-east-1-bos-1a":        localZoneType,
+				"us-east-1-wl1-bos-wlz-1": wavelengthZoneType,
+			},
+			taggingPolicy:           albo.ManualSubnetTaggingPolicy,
+			expectedPublicSubnets:   []string{"subnet-1"},
+			expectedUntaggedSubnets: []string{"subnet-2", "subnet-3", "subnet-4"},
+			expectedSubnetDetails: []albo.AWSLoadBalancerControllerSubnetDetails{
+				{
+					ID:                      "subnet-1",
+					Role:                    albo.PublicSubnetRole,
+					Reason:                  "subnet was tagged with kubernetes.io/role/elb by the user",
+					RoleSource:              albo.UserSubnetRoleSource,
+					AvailabilityZone:        "us-east-1a",
+					ZoneType:                albo.AvailabilityZoneSubnetZoneType,
+					IPv4CIDR:                "10.0.0.0/24",
+					IPv6CIDRs:               []string{"2600:1f18::/64"},
+					AvailableIPAddressCount: 250,
+				},
+				{
+					ID:               "subnet-2",
+					Reason:           "subnet has no role tag",
+					AvailabilityZone: "us-east-1-bos-1a",
+					ZoneType:         albo.LocalZoneSubnetZoneType,
+					IPv4CIDR:         "10.0.1.0/24",
+				},
+				{
+					ID:                      "subnet-3",
+					Reason:                  "subnet has no role tag",
+					AvailabilityZone:        "us-east-1-wl1-bos-wlz-1",
+					ZoneType:                albo.WavelengthZoneSubnetZoneType,
+					IPv4CIDR:                "10.0.2.0/24",
+					AvailableIPAddressCount: 10,
+				},
+				{
+					ID:                      "subnet-4",
+					Reason:                  "subnet has no role tag",
+					AvailabilityZone:        "us-east-1a",
+					ZoneType:                albo.OutpostSubnetZoneType,
+					IPv4CIDR:                "10.0.3.0/24",
+					AvailableIPAddressCount: 20,
+				},
+			},
+		},
+		{
+			name: "manual tagging, subnet selection is ignored",
+			currentSubnets: []ec2types.Subnet{
+				testSubnet("subnet-1"),
+			},
+			selection: &albo.AWSLoadBalancerControllerSubnets{
+				Public: &albo.SubnetSelector{IDs: []albo.SubnetID{"subnet-1"}},
+			},
+			taggingPolicy:           albo.ManualSubnetTaggingPolicy,
+			expectedUntaggedSubnets: []string{"subnet-1"},
+		},
 		{
 			name: "manual tagging, with no preexisting tagged subnets",
 			currentSubnets: []ec2types.Subnet{
@@ -180,24 +676,60 @@ func TestTagSubnets(t *testing.T) {
 			expectedUntaggedSubnets:     []string{"subnet-1"},
 			expectedPublicSubnets:       []string{"subnet-3"},
 		},
+		{
+			name: "manual tagging, with preexisting tagged internal subnets",
+			currentSubnets: []ec2types.Subnet{
+				testSubnet("subnet-1", publicELBTagKey, tagKeyALBOTagged),
+				testSubnet("subnet-2", internalELBTagKey, tagKeyALBOTagged),
+				testSubnet("subnet-3", internalELBTagKey),
+			},
+			taggingPolicy:               albo.ManualSubnetTaggingPolicy,
+			expectedInternalSubnets:     []string{"subnet-3"},
+			expectedRemoveTagOperations: []string{"subnet-1", "subnet-2"},
+			expectedUntaggedSubnets:     []string{"subnet-1", "subnet-2"},
+			expectedSubnetDetails: []albo.AWSLoadBalancerControllerSubnetDetails{
+				{
+					ID:     "subnet-1",
+					Reason: "subnet has no role tag",
+				},
+				{
+					ID:     "subnet-2",
+					Reason: "subnet has no role tag",
+				},
+				{
+					ID:         "subnet-3",
+					Role:       albo.InternalSubnetRole,
+					Reason:     "subnet was tagged with kubernetes.io/role/internal-elb by the user",
+					RoleSource: albo.UserSubnetRoleSource,
+				},
+			},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			controller := testALBC(tc.taggingPolicy)
+			controller.Spec.Subnets = tc.selection
+			controller.Spec.SubnetConflictPolicy = tc.conflictPolicy
 			client := fake.NewClientBuilder().WithScheme(test.Scheme).WithObjects(
 				controller,
 			).Build()
 			ec2Client := &testEC2Client{
-				t:         t,
-				subnets:   tc.currentSubnets,
-				clusterID: "test-cluster",
+				t:                t,
+				subnets:          tc.currentSubnets,
+				accountSubnets:   tc.accountSubnets,
+				zoneTypes:        tc.zoneTypes,
+				routeTables:      tc.routeTables,
+				internetGateways: tc.internetGateways,
+				clusterID:        "test-cluster",
+				vpcID:            "test-vpc",
 			}
 			r := &AWSLoadBalancerControllerReconciler{
 				Client:      client,
 				EC2Client:   ec2Client,
 				ClusterName: "test-cluster",
+				VPCID:       "test-vpc",
 			}
 
-			internal, public, untagged, tagged, err := r.tagSubnets(context.Background(), controller)
+			internal, public, untagged, tagged, conflicting, details, selectionConflicts, err := r.tagSubnets(context.Background(), controller)
 			if err != nil {
 				t.Errorf("got unexpected error: %v", err)
 				return
@@ -207,10 +739,30 @@ func TestTagSubnets(t *testing.T) {
 				t.Errorf("expected subnets %v to be tagged, instead got %v", tc.expectedCreateTagOperations, ec2Client.taggedResources)
 			}
 
+			if !utils.EqualStrings(tc.expectedCreateInternalTagOperations, ec2Client.internalTaggedResources) {
+				t.Errorf("expected subnets %v to be tagged as internal, instead got %v", tc.expectedCreateInternalTagOperations, ec2Client.internalTaggedResources)
+			}
+
 			if !utils.EqualStrings(tc.expectedRemoveTagOperations, ec2Client.untaggedResources) {
 				t.Errorf("expected subnets %v to have been untagged, instead got %v", tc.expectedRemoveTagOperations, ec2Client.untaggedResources)
 			}
 
+			if !utils.EqualStrings(tc.expectedRemoveRoleTagOperations, ec2Client.roleUntaggedResources) {
+				t.Errorf("expected subnets %v to have a role tag removed, instead got %v", tc.expectedRemoveRoleTagOperations, ec2Client.roleUntaggedResources)
+			}
+
+			if !utils.EqualStrings(tc.expectedResolveConflictOperations, ec2Client.conflictResolvedResources) {
+				t.Errorf("expected subnets %v to have the operator's role tag removed, instead got %v", tc.expectedResolveConflictOperations, ec2Client.conflictResolvedResources)
+			}
+
+			if !utils.EqualStrings(tc.expectedConflictingSubnets, conflicting) {
+				t.Errorf("expected conflicting subnets %v, got %v", tc.expectedConflictingSubnets, conflicting)
+			}
+
+			if !utils.EqualStrings(tc.expectedSelectionConflicts, selectionConflicts) {
+				t.Errorf("expected selection conflicts %v, got %v", tc.expectedSelectionConflicts, selectionConflicts)
+			}
+
 			if !utils.EqualStrings(tc.expectedPublicSubnets, public) {
 				t.Errorf("expected public subnets %v, got %v", tc.expectedPublicSubnets, public)
 			}
@@ -223,8 +775,337 @@ func TestTagSubnets(t *testing.T) {
 			if !utils.EqualStrings(tc.expectedUntaggedSubnets, untagged) {
 				t.Errorf("expected untagged subnets %v, got %v", tc.expectedUntaggedSubnets, untagged)
 			}
+			if tc.expectedSubnetDetails != nil {
+				if diff := cmp.Diff(tc.expectedSubnetDetails, details); diff != "" {
+					t.Errorf("unexpected subnet details (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestSyncSubnets(t *testing.T) {
+	for _, tc := range []struct {
+		name                   string
+		statusSubnets          *albo.AWSLoadBalancerControllerStatusSubnets
+		taggingPolicy          albo.SubnetTaggingPolicy
+		resyncInterval         *metav1.Duration
+		sinceLastSync          time.Duration
+		subnetSpecChanged      bool
+		currentSubnets         []ec2types.Subnet
+		expectedSynced         bool
+		expectedStatusSubnets  *albo.AWSLoadBalancerControllerStatusSubnets
+		expectedEvents         []string
+		statusConditions       []metav1.Condition
+		minAvailableIPs        *int32
+		expectedConditions     []metav1.Condition
+		expectedError          string
+		expectedStatusUpdated  bool
+		expectedNextSyncAtMost time.Duration
+	}{
+		{
+			name:          "first sync",
+			taggingPolicy: albo.ManualSubnetTaggingPolicy,
+			currentSubnets: []ec2types.Subnet{
+				testSubnet("subnet-1", publicELBTagKey),
+				testSubnet("subnet-2"),
+			},
+			expectedSynced: true,
+			expectedStatusSubnets: &albo.AWSLoadBalancerControllerStatusSubnets{
+				SubnetTagging: albo.ManualSubnetTaggingPolicy,
+				Public:        []string{"subnet-1"},
+				Untagged:      []string{"subnet-2"},
+				Details: []albo.AWSLoadBalancerControllerSubnetDetails{
+					{ID: "subnet-1", Role: albo.PublicSubnetRole, Reason: "subnet was tagged with kubernetes.io/role/elb by the user", RoleSource: albo.UserSubnetRoleSource},
+					{ID: "subnet-2", Reason: "subnet has no role tag"},
+				},
+			},
+			expectedEvents:         []string{"Normal SubnetsChanged Cluster subnets changed; added: subnet-1, subnet-2"},
+			expectedStatusUpdated:  true,
+			expectedNextSyncAtMost: defaultSubnetResyncInterval,
+		},
+		{
+			name:          "resync interval not elapsed",
+			taggingPolicy: albo.ManualSubnetTaggingPolicy,
+			statusSubnets: &albo.AWSLoadBalancerControllerStatusSubnets{
+				SubnetTagging: albo.ManualSubnetTaggingPolicy,
+				Public:        []string{"subnet-1"},
+			},
+			resyncInterval: &metav1.Duration{Duration: time.Hour},
+			sinceLastSync:  10 * time.Minute,
+			currentSubnets: []ec2types.Subnet{
+				testSubnet("subnet-1", publicELBTagKey),
+				testSubnet("subnet-2"),
+			},
+			expectedStatusSubnets: &albo.AWSLoadBalancerControllerStatusSubnets{
+				SubnetTagging: albo.ManualSubnetTaggingPolicy,
+				Public:        []string{"subnet-1"},
+			},
+			expectedNextSyncAtMost: 50 * time.Minute,
+		},
+		{
+			name:          "resync interval not elapsed, subnet spec changed",
+			taggingPolicy: albo.ManualSubnetTaggingPolicy,
+			statusSubnets: &albo.AWSLoadBalancerControllerStatusSubnets{
+				SubnetTagging: albo.ManualSubnetTaggingPolicy,
+				Public:        []string{"subnet-1"},
+			},
+			resyncInterval:    &metav1.Duration{Duration: time.Hour},
+			sinceLastSync:     10 * time.Minute,
+			subnetSpecChanged: true,
+			minAvailableIPs:   ptr.To[int32](0),
+			currentSubnets: []ec2types.Subnet{
+				testSubnet("subnet-1", publicELBTagKey),
+			},
+			expectedSynced: true,
+			expectedStatusSubnets: &albo.AWSLoadBalancerControllerStatusSubnets{
+				SubnetTagging: albo.ManualSubnetTaggingPolicy,
+				Public:        []string{"subnet-1"},
+				Details: []albo.AWSLoadBalancerControllerSubnetDetails{
+					{ID: "subnet-1", Role: albo.PublicSubnetRole, Reason: "subnet was tagged with kubernetes.io/role/elb by the user", RoleSource: albo.UserSubnetRoleSource},
+				},
+			},
+			expectedStatusUpdated:  true,
+			expectedNextSyncAtMost: time.Hour,
+		},
+		{
+			name:          "resync interval elapsed, subnets changed",
+			taggingPolicy: albo.ManualSubnetTaggingPolicy,
+			statusSubnets: &albo.AWSLoadBalancerControllerStatusSubnets{
+				SubnetTagging: albo.ManualSubnetTaggingPolicy,
+				Public:        []string{"subnet-1"},
+				Internal:      []string{"subnet-3"},
+				Untagged:      []string{"subnet-4"},
+			},
+			resyncInterval: &metav1.Duration{Duration: time.Hour},
+			sinceLastSync:  2 * time.Hour,
+			currentSubnets: []ec2types.Subnet{
+				testSubnet("subnet-1", publicELBTagKey),
+				testSubnet("subnet-2"),
+				testSubnet("subnet-4", internalELBTagKey),
+			},
+			expectedSynced: true,
+			expectedStatusSubnets: &albo.AWSLoadBalancerControllerStatusSubnets{
+				SubnetTagging: albo.ManualSubnetTaggingPolicy,
+				Public:        []string{"subnet-1"},
+				Internal:      []string{"subnet-4"},
+				Untagged:      []string{"subnet-2"},
+				Details: []albo.AWSLoadBalancerControllerSubnetDetails{
+					{ID: "subnet-1", Role: albo.PublicSubnetRole, Reason: "subnet was tagged with kubernetes.io/role/elb by the user", RoleSource: albo.UserSubnetRoleSource},
+					{ID: "subnet-2", Reason: "subnet has no role tag"},
+					{ID: "subnet-4", Role: albo.InternalSubnetRole, Reason: "subnet was tagged with kubernetes.io/role/internal-elb by the user", RoleSource: albo.UserSubnetRoleSource},
+				},
+			},
+			expectedEvents:         []string{"Normal SubnetsChanged Cluster subnets changed; added: subnet-2; removed: subnet-3; re-tagged: subnet-4 (Untagged -> Internal)"},
+			expectedStatusUpdated:  true,
+			expectedNextSyncAtMost: time.Hour,
+		},
+		{
+			name:          "resync interval elapsed, subnets unchanged",
+			taggingPolicy: albo.ManualSubnetTaggingPolicy,
+			statusSubnets: &albo.AWSLoadBalancerControllerStatusSubnets{
+				SubnetTagging: albo.ManualSubnetTaggingPolicy,
+				Public:        []string{"subnet-1"},
+				Details: []albo.AWSLoadBalancerControllerSubnetDetails{
+					{ID: "subnet-1", Role: albo.PublicSubnetRole, Reason: "subnet was tagged with kubernetes.io/role/elb by the user", RoleSource: albo.UserSubnetRoleSource},
+				},
+			},
+			statusConditions: []metav1.Condition{
+				{Type: SubnetsReadyCondition, Status: metav1.ConditionFalse, Reason: "InsufficientAvailabilityZones", Message: "Public subnets span no availability zone, at least 2 availability zones are required by application load balancers; Internal subnets span no availability zone, at least 2 availability zones are required by application load balancers", LastTransitionTime: metav1.Now()},
+				{Type: SubnetsDegradedCondition, Status: metav1.ConditionTrue, Reason: "InsufficientAvailabilityZones", Message: "Public subnets span no availability zone, at least 2 availability zones are required by application load balancers; Internal subnets span no availability zone, at least 2 availability zones are required by application load balancers", LastTransitionTime: metav1.Now()},
+			},
+			minAvailableIPs: ptr.To[int32](0),
+			sinceLastSync:   2 * defaultSubnetResyncInterval,
+			currentSubnets: []ec2types.Subnet{
+				testSubnet("subnet-1", publicELBTagKey),
+			},
+			expectedConditions: []metav1.Condition{
+				{Type: SubnetsReadyCondition, Status: metav1.ConditionFalse, Reason: "InsufficientAvailabilityZones"},
+				{Type: SubnetsDegradedCondition, Status: metav1.ConditionTrue, Reason: "InsufficientAvailabilityZones"},
+			},
+			expectedSynced: true,
+			expectedStatusSubnets: &albo.AWSLoadBalancerControllerStatusSubnets{
+				SubnetTagging: albo.ManualSubnetTaggingPolicy,
+				Public:        []string{"subnet-1"},
+				Details: []albo.AWSLoadBalancerControllerSubnetDetails{
+					{ID: "subnet-1", Role: albo.PublicSubnetRole, Reason: "subnet was tagged with kubernetes.io/role/elb by the user", RoleSource: albo.UserSubnetRoleSource},
+				},
+			},
+			expectedNextSyncAtMost: defaultSubnetResyncInterval,
+		},
+		{
+			name:          "subnets with both role tags",
+			taggingPolicy: albo.ManualSubnetTaggingPolicy,
+			statusSubnets: &albo.AWSLoadBalancerControllerStatusSubnets{
+				SubnetTagging: albo.ManualSubnetTaggingPolicy,
+				Public:        []string{"subnet-1"},
+			},
+			sinceLastSync: 2 * defaultSubnetResyncInterval,
+			currentSubnets: []ec2types.Subnet{
+				testSubnet("subnet-1", publicELBTagKey, internalELBTagKey),
+			},
+			expectedSynced: true,
+			expectedStatusSubnets: &albo.AWSLoadBalancerControllerStatusSubnets{
+				SubnetTagging: albo.ManualSubnetTaggingPolicy,
+				Conflicting:   []string{"subnet-1"},
+				Details: []albo.AWSLoadBalancerControllerSubnetDetails{
+					{ID: "subnet-1", Reason: "subnet has both tags kubernetes.io/role/internal-elb and kubernetes.io/role/elb"},
+				},
+			},
+			expectedEvents: []string{
+				"Normal SubnetsChanged Cluster subnets changed; re-tagged: subnet-1 (Public -> Conflicting)",
+			},
+			expectedConditions: []metav1.Condition{
+				{Type: SubnetsReadyCondition, Status: metav1.ConditionFalse, Reason: "DualRoleSubnets"},
+				{Type: SubnetsDegradedCondition, Status: metav1.ConditionTrue, Reason: "DualRoleSubnets"},
+			},
+			expectedStatusUpdated:  true,
+			expectedNextSyncAtMost: defaultSubnetResyncInterval,
+		},
+		{
+			name:          "tagging policy changed",
+			taggingPolicy: albo.AutoSubnetTaggingPolicy,
+			statusSubnets: &albo.AWSLoadBalancerControllerStatusSubnets{
+				SubnetTagging: albo.ManualSubnetTaggingPolicy,
+				Public:        []string{"subnet-1"},
+			},
+			currentSubnets: []ec2types.Subnet{
+				testSubnet("subnet-1", publicELBTagKey),
+			},
+			expectedSynced: true,
+			expectedStatusSubnets: &albo.AWSLoadBalancerControllerStatusSubnets{
+				SubnetTagging: albo.AutoSubnetTaggingPolicy,
+				Public:        []string{"subnet-1"},
+				Details: []albo.AWSLoadBalancerControllerSubnetDetails{
+					{ID: "subnet-1", Role: albo.PublicSubnetRole, Reason: "subnet was tagged with kubernetes.io/role/elb by the user", RoleSource: albo.UserSubnetRoleSource},
+				},
+			},
+			expectedStatusUpdated:  true,
+			expectedNextSyncAtMost: defaultSubnetResyncInterval,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := &albo.AWSLoadBalancerController{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Spec: albo.AWSLoadBalancerControllerSpec{
+					SubnetTagging:                 tc.taggingPolicy,
+					SubnetResyncInterval:          tc.resyncInterval,
+					MinSubnetAvailableIPAddresses: tc.minAvailableIPs,
+				},
+				Status: albo.AWSLoadBalancerControllerStatus{
+					Subnets:    tc.statusSubnets,
+					Conditions: tc.statusConditions,
+				},
+			}
+			client := fake.NewClientBuilder().WithScheme(test.Scheme).WithObjects(controller).WithStatusSubresource(controller).Build()
+			if err := client.Get(context.Background(), types.NamespacedName{Name: controller.Name}, controller); err != nil {
+				t.Fatalf("failed to get the controller: %v", err)
+			}
+			initialResourceVersion := controller.ResourceVersion
+			recorder := record.NewFakeRecorder(10)
+			r := &AWSLoadBalancerControllerReconciler{
+				Client: client,
+				EC2Client: &testEC2Client{
+					t:         t,
+					subnets:   tc.currentSubnets,
+					clusterID: "test-cluster",
+					vpcID:     "test-vpc",
+				},
+				ClusterName:     "test-cluster",
+				VPCID:           "test-vpc",
+				Recorder:        recorder,
+				subnetsSyncedAt: time.Now().Add(-tc.sinceLastSync),
+			}
+
+			if !tc.subnetSpecChanged {
+				hash, err := subnetSpecHash(controller)
+				if err != nil {
+					t.Fatalf("failed to build subnet spec hash: %v", err)
+				}
+				r.subnetsSyncedHash = hash
+			}
+
+			synced, nextSync, err := r.syncSubnets(context.Background(), controller)
+			if tc.expectedError != "" {
+				if err == nil || err.Error() != tc.expectedError {
+					t.Errorf("expected error %q, got %v", tc.expectedError, err)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("got unexpected error: %v", err)
+				}
+				if nextSync <= 0 || nextSync > tc.expectedNextSyncAtMost {
+					t.Errorf("expected next sync in at most %v, got %v", tc.expectedNextSyncAtMost, nextSync)
+				}
+			}
+			if synced != tc.expectedSynced {
+				t.Errorf("expected synced to be %t, got %t", tc.expectedSynced, synced)
+			}
+
+			close(recorder.Events)
+			var events []string
+			for event := range recorder.Events {
+				events = append(events, event)
+			}
+			if diff := cmp.Diff(tc.expectedEvents, events); diff != "" {
+				t.Errorf("unexpected events (-want +got):\n%s", diff)
+			}
+
+			var updated albo.AWSLoadBalancerController
+			if err := client.Get(context.Background(), types.NamespacedName{Name: controller.Name}, &updated); err != nil {
+				t.Fatalf("failed to get the controller: %v", err)
+			}
+			if statusUpdated := updated.ResourceVersion != initialResourceVersion; statusUpdated != tc.expectedStatusUpdated {
+				t.Errorf("expected status updated to be %t, got %t", tc.expectedStatusUpdated, statusUpdated)
+			}
+			if diff := cmp.Diff(tc.expectedStatusSubnets, updated.Status.Subnets, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("unexpected subnets status (-want +got):\n%s", diff)
+			}
+			if tc.expectedConditions != nil {
+				if diff := cmp.Diff(tc.expectedConditions, updated.Status.Conditions, cmpopts.IgnoreFields(metav1.Condition{}, "Message", "LastTransitionTime")); diff != "" {
+					t.Errorf("unexpected conditions (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func testAccountSubnet(id, vpcID, zone string, tags ...ec2types.Tag) ec2types.Subnet {
+	return ec2types.Subnet{
+		SubnetId:         awstypes.String(id),
+		VpcId:            awstypes.String(vpcID),
+		AvailabilityZone: awstypes.String(zone),
+		Tags:             tags,
+	}
+}
+
+func testRouteTable(id string, main bool, subnetIDs []string, routes ...ec2types.Route) ec2types.RouteTable {
+	rt := ec2types.RouteTable{
+		RouteTableId: awstypes.String(id),
+		Routes:       routes,
+	}
+	if main {
+		rt.Associations = append(rt.Associations, ec2types.RouteTableAssociation{
+			Main:         awstypes.Bool(true),
+			RouteTableId: awstypes.String(id),
 		})
 	}
+	for _, subnetID := range subnetIDs {
+		rt.Associations = append(rt.Associations, ec2types.RouteTableAssociation{
+			Main:         awstypes.Bool(false),
+			RouteTableId: awstypes.String(id),
+			SubnetId:     awstypes.String(subnetID),
+		})
+	}
+	return rt
+}
+
+func testIGWRoute(gatewayID string) ec2types.Route {
+	return ec2types.Route{
+		DestinationCidrBlock: awstypes.String("0.0.0.0/0"),
+		GatewayId:            awstypes.String(gatewayID),
+		State:                ec2types.RouteStateActive,
+	}
 }
 
 func testALBC(taggingPolicy albo.SubnetTaggingPolicy) *albo.AWSLoadBalancerController {
@@ -240,18 +1121,34 @@ func testALBC(taggingPolicy albo.SubnetTaggingPolicy) *albo.AWSLoadBalancerContr
 }
 
 type testEC2Client struct {
-	t                 *testing.T
-	subnets           []ec2types.Subnet
-	clusterID         string
-	taggedResources   []string
-	untaggedResources []string
+	t                       *testing.T
+	subnets                 []ec2types.Subnet
+	routeTables             []ec2types.RouteTable
+	internetGateways        []string
+	clusterID               string
+	vpcID                   string
+	taggedResources         []string
+	internalTaggedResources []string
+	untaggedResources       []string
+	roleUntaggedResources   []string
+	// conflictResolvedResources are the subnets which had the operator's role tag removed along with the operator's tag
+	conflictResolvedResources []string
+	// accountSubnets are all the subnets of the account, including the ones of the other VPCs
+	accountSubnets []ec2types.Subnet
+	// zoneTypes are the types of the zones, the zones which are not in the map are regular availability zones
+	zoneTypes map[string]string
 	aws.VPCClient
+	aws.SecurityGroupClient
 }
 
 var badQueryError = errors.New("bad query")
 
 func (t *testEC2Client) DescribeSubnets(_ context.Context, input *ec2.DescribeSubnetsInput, _ ...func(*ec2.Options)) (*ec2.DescribeSubnetsOutput, error) {
 	t.t.Helper()
+	// the queries of the selected subnets are evaluated against all the subnets of the account
+	if len(input.Filters) != 1 || awstypes.ToString(input.Filters[0].Name) != tagKeyFilterName {
+		return &ec2.DescribeSubnetsOutput{Subnets: filterTestSubnets(t.accountSubnets, input.Filters)}, nil
+	}
 	if len(input.Filters) != 1 {
 		t.t.Errorf("query does not have correct number of filters")
 		return nil, badQueryError
@@ -271,27 +1168,105 @@ func (t *testEC2Client) DescribeSubnets(_ context.Context, input *ec2.DescribeSu
 	return &ec2.DescribeSubnetsOutput{Subnets: t.subnets}, nil
 }
 
+func (t *testEC2Client) DescribeAvailabilityZones(_ context.Context, input *ec2.DescribeAvailabilityZonesInput, _ ...func(*ec2.Options)) (*ec2.DescribeAvailabilityZonesOutput, error) {
+	t.t.Helper()
+	if !awstypes.ToBool(input.AllAvailabilityZones) {
+		t.t.Errorf("all availability zones are expected to be requested")
+		return nil, badQueryError
+	}
+	output := &ec2.DescribeAvailabilityZonesOutput{}
+	for _, name := range input.ZoneNames {
+		zoneType, found := t.zoneTypes[name]
+		if !found {
+			zoneType = availabilityZoneType
+		}
+		output.AvailabilityZones = append(output.AvailabilityZones, ec2types.AvailabilityZone{
+			ZoneName: awstypes.String(name),
+			ZoneType: awstypes.String(zoneType),
+		})
+	}
+	return output, nil
+}
+
+// filterTestSubnets returns the subnets matching all the given filters.
+func filterTestSubnets(subnets []ec2types.Subnet, filters []ec2types.Filter) []ec2types.Subnet {
+	var matching []ec2types.Subnet
+	for _, s := range subnets {
+		matches := true
+		for _, f := range filters {
+			values := sets.New[string](f.Values...)
+			switch name := awstypes.ToString(f.Name); {
+			case name == subnetIDFilterName:
+				matches = matches && values.Has(awstypes.ToString(s.SubnetId))
+			case name == vpcIDFilterName:
+				matches = matches && values.Has(awstypes.ToString(s.VpcId))
+			case name == availabilityZoneFilterName:
+				matches = matches && values.Has(awstypes.ToString(s.AvailabilityZone))
+			case name == tagKeyFilterName:
+				matches = matches && hasTag(s.Tags, f.Values[0])
+			case strings.HasPrefix(name, tagFilterNamePrefix):
+				found := false
+				for _, tag := range s.Tags {
+					if awstypes.ToString(tag.Key) == strings.TrimPrefix(name, tagFilterNamePrefix) && values.Has(awstypes.ToString(tag.Value)) {
+						found = true
+					}
+				}
+				matches = matches && found
+			default:
+				matches = false
+			}
+		}
+		if matches {
+			matching = append(matching, s)
+		}
+	}
+	return matching
+}
+
 func (t *testEC2Client) CreateTags(_ context.Context, input *ec2.CreateTagsInput, _ ...func(*ec2.Options)) (*ec2.CreateTagsOutput, error) {
 	t.t.Helper()
 	if len(input.Tags) != 2 {
 		t.t.Errorf("unexpected number of tags: %d", len(input.Tags))
 		return nil, badQueryError
 	}
-	if !hasTag(input.Tags, publicELBTagKey) {
-		t.t.Errorf("input %v does not have tag key %s", input.Tags, publicELBTagKey)
-		return nil, badQueryError
-	}
 	if !hasTag(input.Tags, tagKeyALBOTagged) {
 		t.t.Errorf("input %v does not have tag key %s", input.Tags, tagKeyALBOTagged)
 		return nil, badQueryError
 	}
-	t.taggedResources = append(t.taggedResources, input.Resources...)
+	switch {
+	case hasTag(input.Tags, publicELBTagKey):
+		t.taggedResources = append(t.taggedResources, input.Resources...)
+	case hasTag(input.Tags, internalELBTagKey):
+		t.internalTaggedResources = append(t.internalTaggedResources, input.Resources...)
+	default:
+		t.t.Errorf("input %v does not have any role tag key", input.Tags)
+		return nil, badQueryError
+	}
 	return nil, nil
 }
 
 func (t *testEC2Client) DeleteTags(ctx context.Context, input *ec2.DeleteTagsInput, _ ...func(*ec2.Options)) (*ec2.DeleteTagsOutput, error) {
 	t.t.Helper()
-	if len(input.Tags) != 2 {
+	// a single role tag is removed from the subnets re-tagged with the other role
+	if len(input.Tags) == 1 {
+		key := awstypes.ToString(input.Tags[0].Key)
+		if key != publicELBTagKey && key != internalELBTagKey {
+			t.t.Errorf("input %v does not have any role tag key", input.Tags)
+			return nil, badQueryError
+		}
+		t.roleUntaggedResources = append(t.roleUntaggedResources, input.Resources...)
+		return nil, nil
+	}
+	// a single role tag is removed along with the operator's tag from the conflicting subnets
+	if len(input.Tags) == 2 {
+		if !hasTag(input.Tags, tagKeyALBOTagged) || (!hasTag(input.Tags, publicELBTagKey) && !hasTag(input.Tags, internalELBTagKey)) {
+			t.t.Errorf("input %v does not have a role tag key and tag key %s", input.Tags, tagKeyALBOTagged)
+			return nil, badQueryError
+		}
+		t.conflictResolvedResources = append(t.conflictResolvedResources, input.Resources...)
+		return nil, nil
+	}
+	if len(input.Tags) != 3 {
 		t.t.Errorf("unexpected number of tags: %d", len(input.Tags))
 		return nil, badQueryError
 	}
@@ -299,6 +1274,10 @@ func (t *testEC2Client) DeleteTags(ctx context.Context, input *ec2.DeleteTagsInp
 		t.t.Errorf("input %v does not have tag key %s", input.Tags, publicELBTagKey)
 		return nil, badQueryError
 	}
+	if !hasTag(input.Tags, internalELBTagKey) {
+		t.t.Errorf("input %v does not have tag key %s", input.Tags, internalELBTagKey)
+		return nil, badQueryError
+	}
 	if !hasTag(input.Tags, tagKeyALBOTagged) {
 		t.t.Errorf("input %v does not have tag key %s", input.Tags, tagKeyALBOTagged)
 		return nil, badQueryError
@@ -306,3 +1285,33 @@ func (t *testEC2Client) DeleteTags(ctx context.Context, input *ec2.DeleteTagsInp
 	t.untaggedResources = append(t.untaggedResources, input.Resources...)
 	return nil, nil
 }
+
+func (t *testEC2Client) DescribeRouteTables(_ context.Context, input *ec2.DescribeRouteTablesInput, _ ...func(*ec2.Options)) (*ec2.DescribeRouteTablesOutput, error) {
+	t.t.Helper()
+	if len(input.Filters) != 1 || awstypes.ToString(input.Filters[0].Name) != vpcIDFilterName {
+		t.t.Errorf("unexpected filters %v", input.Filters)
+		return nil, badQueryError
+	}
+	if len(input.Filters[0].Values) != 1 || input.Filters[0].Values[0] != t.vpcID {
+		t.t.Errorf("unexpected filter values %v for name %s", input.Filters[0].Values, vpcIDFilterName)
+		return nil, badQueryError
+	}
+	return &ec2.DescribeRouteTablesOutput{RouteTables: t.routeTables}, nil
+}
+
+func (t *testEC2Client) DescribeInternetGateways(_ context.Context, input *ec2.DescribeInternetGatewaysInput, _ ...func(*ec2.Options)) (*ec2.DescribeInternetGatewaysOutput, error) {
+	t.t.Helper()
+	if len(input.Filters) != 1 || awstypes.ToString(input.Filters[0].Name) != attachmentVPCIDFilterName {
+		t.t.Errorf("unexpected filters %v", input.Filters)
+		return nil, badQueryError
+	}
+	if len(input.Filters[0].Values) != 1 || input.Filters[0].Values[0] != t.vpcID {
+		t.t.Errorf("unexpected filter values %v for name %s", input.Filters[0].Values, attachmentVPCIDFilterName)
+		return nil, badQueryError
+	}
+	output := &ec2.DescribeInternetGatewaysOutput{}
+	for _, id := range t.internetGateways {
+		output.InternetGateways = append(output.InternetGateways, ec2types.InternetGateway{InternetGatewayId: awstypes.String(id)})
+	}
+	return output, nil
+}
@@ -0,0 +1,114 @@
+package awsloadbalancercontroller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	awstypes "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	elbv2 "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/smithy-go"
+
+	"github.com/openshift/aws-load-balancer-operator/pkg/aws"
+)
+
+const (
+	// elbv2ClusterTagKey is the tag put by the controller on the AWS resources it provisions for the cluster.
+	elbv2ClusterTagKey = "elbv2.k8s.aws/cluster"
+	// dependencyViolationErrorCode is the error code of the deletion of a security group
+	// which is still used by a network interface.
+	dependencyViolationErrorCode = "DependencyViolation"
+)
+
+// loadBalancerResources are the AWS resources provisioned by the controller for the cluster.
+type loadBalancerResources struct {
+	loadBalancers  []string
+	targetGroups   []string
+	securityGroups []string
+	// inUseSecurityGroups are the security groups which couldn't be deleted yet
+	// because the network interfaces of the deleted load balancers are not released.
+	inUseSecurityGroups []string
+}
+
+// deleteLoadBalancerResources deletes the AWS resources provisioned by the controller for the cluster
+// in the dependency order: the load balancers with their listeners, then the target groups
+// and finally the security groups. The deletion stops at the first failure,
+// the resources which depend on the failed one would fail to be deleted too.
+// The security groups still in use are not a failure, they are expected to be deleted later.
+// The deleted resources are returned along with the security groups still in use.
+func (r *AWSLoadBalancerControllerReconciler) deleteLoadBalancerResources(ctx context.Context) (*loadBalancerResources, error) {
+	deleted := &loadBalancerResources{}
+
+	loadBalancers, err := aws.GetClusterLoadBalancers(ctx, r.ELBv2Client, r.ClusterName, r.VPCID)
+	if err != nil {
+		return deleted, err
+	}
+	for _, lb := range loadBalancers {
+		lbARN := awstypes.ToString(lb.LoadBalancerArn)
+		// the listeners are deleted along with the load balancer
+		if _, err := r.ELBv2Client.DeleteLoadBalancer(ctx, &elbv2.DeleteLoadBalancerInput{LoadBalancerArn: awstypes.String(lbARN)}); err != nil {
+			return deleted, fmt.Errorf("failed to delete load balancer %s: %w", lbARN, err)
+		}
+		deleted.loadBalancers = append(deleted.loadBalancers, lbARN)
+	}
+
+	targetGroupARNs, err := aws.GetClusterTargetGroups(ctx, r.ELBv2Client, r.ClusterName, r.VPCID)
+	if err != nil {
+		return deleted, err
+	}
+	for _, tgARN := range targetGroupARNs {
+		if _, err := r.ELBv2Client.DeleteTargetGroup(ctx, &elbv2.DeleteTargetGroupInput{TargetGroupArn: awstypes.String(tgARN)}); err != nil {
+			return deleted, fmt.Errorf("failed to delete target group %s: %w", tgARN, err)
+		}
+		deleted.targetGroups = append(deleted.targetGroups, tgARN)
+	}
+
+	securityGroupIDs, err := r.clusterSecurityGroups(ctx)
+	if err != nil {
+		return deleted, fmt.Errorf("failed to list security groups: %w", err)
+	}
+	for _, sgID := range securityGroupIDs {
+		if _, err := r.EC2Client.DeleteSecurityGroup(ctx, &ec2.DeleteSecurityGroupInput{GroupId: awstypes.String(sgID)}); err != nil {
+			// the deletion fails until the network interfaces of the deleted load balancers are released
+			var apiErr smithy.APIError
+			if errors.As(err, &apiErr) && apiErr.ErrorCode() == dependencyViolationErrorCode {
+				deleted.inUseSecurityGroups = append(deleted.inUseSecurityGroups, sgID)
+				continue
+			}
+			return deleted, fmt.Errorf("failed to delete security group %s: %w", sgID, err)
+		}
+		deleted.securityGroups = append(deleted.securityGroups, sgID)
+	}
+
+	return deleted, nil
+}
+
+// clusterSecurityGroups returns the ids of the security groups of the cluster VPC
+// which are tagged with the cluster tag of the controller.
+func (r *AWSLoadBalancerControllerReconciler) clusterSecurityGroups(ctx context.Context) ([]string, error) {
+	var ids []string
+	paginator := ec2.NewDescribeSecurityGroupsPaginator(r.EC2Client, &ec2.DescribeSecurityGroupsInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   awstypes.String(vpcIDFilterName),
+				Values: []string{r.VPCID},
+			},
+			{
+				Name:   awstypes.String(tagFilterNamePrefix + elbv2ClusterTagKey),
+				Values: []string{r.ClusterName},
+			},
+		},
+	})
+	for paginator.HasMorePages() {
+		response, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, sg := range response.SecurityGroups {
+			ids = append(ids, awstypes.ToString(sg.GroupId))
+		}
+	}
+	return ids, nil
+}
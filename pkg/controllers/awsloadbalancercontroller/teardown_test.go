@@ -0,0 +1,304 @@
+package awsloadbalancercontroller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	awstypes "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	elbv2 "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/aws/smithy-go"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/openshift/aws-load-balancer-operator/pkg/aws"
+)
+
+func TestDeleteLoadBalancerResources(t *testing.T) {
+	for _, tc := range []struct {
+		name               string
+		loadBalancers      []elbv2types.LoadBalancer
+		targetGroups       []elbv2types.TargetGroup
+		tags               map[string]string
+		securityGroups     []ec2types.SecurityGroup
+		failingDeletions   map[string]error
+		expectedOperations []string
+		expectedInUse      []string
+		expectedError      string
+	}{
+		{
+			name: "no resources",
+		},
+		{
+			name: "resources deleted in dependency order",
+			loadBalancers: []elbv2types.LoadBalancer{
+				testLoadBalancer("lb-1", "test-vpc"),
+				testLoadBalancer("lb-2", "test-vpc"),
+				testLoadBalancer("lb-other-cluster", "test-vpc"),
+				testLoadBalancer("lb-untagged", "test-vpc"),
+				testLoadBalancer("lb-other-vpc", "other-vpc"),
+			},
+			targetGroups: []elbv2types.TargetGroup{
+				testTargetGroup("tg-1", "test-vpc"),
+				testTargetGroup("tg-other-cluster", "test-vpc"),
+				testTargetGroup("tg-other-vpc", "other-vpc"),
+			},
+			tags: map[string]string{
+				"lb-1":             "test-cluster",
+				"lb-2":             "test-cluster",
+				"lb-other-cluster": "other-cluster",
+				"lb-other-vpc":     "test-cluster",
+				"tg-1":             "test-cluster",
+				"tg-other-cluster": "other-cluster",
+				"tg-other-vpc":     "test-cluster",
+			},
+			securityGroups: []ec2types.SecurityGroup{
+				testSecurityGroup("sg-1", "test-vpc", "test-cluster"),
+				testSecurityGroup("sg-other-cluster", "test-vpc", "other-cluster"),
+				testSecurityGroup("sg-other-vpc", "other-vpc", "test-cluster"),
+			},
+			expectedOperations: []string{
+				"DeleteLoadBalancer lb-1",
+				"DeleteLoadBalancer lb-2",
+				"DeleteTargetGroup tg-1",
+				"DeleteSecurityGroup sg-1",
+			},
+		},
+		{
+			name: "dependent resources not deleted after failure",
+			loadBalancers: []elbv2types.LoadBalancer{
+				testLoadBalancer("lb-1", "test-vpc"),
+			},
+			targetGroups: []elbv2types.TargetGroup{
+				testTargetGroup("tg-1", "test-vpc"),
+				testTargetGroup("tg-2", "test-vpc"),
+			},
+			tags: map[string]string{
+				"lb-1": "test-cluster",
+				"tg-1": "test-cluster",
+				"tg-2": "test-cluster",
+			},
+			securityGroups: []ec2types.SecurityGroup{
+				testSecurityGroup("sg-1", "test-vpc", "test-cluster"),
+			},
+			failingDeletions: map[string]error{
+				"tg-1": errors.New("ResourceInUse"),
+			},
+			expectedOperations: []string{
+				"DeleteLoadBalancer lb-1",
+			},
+			expectedError: "failed to delete target group tg-1: ResourceInUse",
+		},
+		{
+			name: "security groups in use are not a failure",
+			loadBalancers: []elbv2types.LoadBalancer{
+				testLoadBalancer("lb-1", "test-vpc"),
+			},
+			tags: map[string]string{
+				"lb-1": "test-cluster",
+			},
+			securityGroups: []ec2types.SecurityGroup{
+				testSecurityGroup("sg-1", "test-vpc", "test-cluster"),
+				testSecurityGroup("sg-2", "test-vpc", "test-cluster"),
+			},
+			failingDeletions: map[string]error{
+				"sg-1": &smithy.GenericAPIError{Code: "DependencyViolation", Message: "resource sg-1 has a dependent object"},
+			},
+			expectedOperations: []string{
+				"DeleteLoadBalancer lb-1",
+				"DeleteSecurityGroup sg-2",
+			},
+			expectedInUse: []string{"sg-1"},
+		},
+		{
+			name: "security group deletion failure",
+			securityGroups: []ec2types.SecurityGroup{
+				testSecurityGroup("sg-1", "test-vpc", "test-cluster"),
+			},
+			failingDeletions: map[string]error{
+				"sg-1": errors.New("AccessDenied"),
+			},
+			expectedError: "failed to delete security group sg-1: AccessDenied",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var operations []string
+			elbv2Client := &testELBv2Client{
+				t:                t,
+				loadBalancers:    tc.loadBalancers,
+				targetGroups:     tc.targetGroups,
+				clusterTags:      tc.tags,
+				failingDeletions: tc.failingDeletions,
+				operations:       &operations,
+			}
+			ec2Client := &testSecurityGroupEC2Client{
+				testEC2Client:    &testEC2Client{t: t},
+				securityGroups:   tc.securityGroups,
+				failingDeletions: tc.failingDeletions,
+				operations:       &operations,
+			}
+			r := &AWSLoadBalancerControllerReconciler{
+				EC2Client:   ec2Client,
+				ELBv2Client: elbv2Client,
+				ClusterName: "test-cluster",
+				VPCID:       "test-vpc",
+			}
+
+			deleted, err := r.deleteLoadBalancerResources(context.Background())
+			if tc.expectedError != "" {
+				if err == nil || err.Error() != tc.expectedError {
+					t.Errorf("expected error %q, got %v", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expectedOperations, operations); diff != "" {
+				t.Errorf("unexpected operations (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedInUse, deleted.inUseSecurityGroups); diff != "" {
+				t.Errorf("unexpected security groups in use (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func testLoadBalancer(arn, vpcID string) elbv2types.LoadBalancer {
+	return elbv2types.LoadBalancer{
+		LoadBalancerArn: awstypes.String(arn),
+		VpcId:           awstypes.String(vpcID),
+	}
+}
+
+func testTargetGroup(arn, vpcID string) elbv2types.TargetGroup {
+	return elbv2types.TargetGroup{
+		TargetGroupArn: awstypes.String(arn),
+		VpcId:          awstypes.String(vpcID),
+	}
+}
+
+func testSecurityGroup(id, vpcID, clusterName string) ec2types.SecurityGroup {
+	return ec2types.SecurityGroup{
+		GroupId: awstypes.String(id),
+		VpcId:   awstypes.String(vpcID),
+		Tags: []ec2types.Tag{
+			{
+				Key:   awstypes.String(elbv2ClusterTagKey),
+				Value: awstypes.String(clusterName),
+			},
+		},
+	}
+}
+
+// testELBv2Client is a fake ELBv2 client which records the deletions in the given operations.
+type testELBv2Client struct {
+	t             *testing.T
+	loadBalancers []elbv2types.LoadBalancer
+	targetGroups  []elbv2types.TargetGroup
+	// clusterTags are the values of the cluster tag of each resource
+	clusterTags map[string]string
+	// tags are the other tags of each resource
+	tags map[string]map[string]string
+	// failingDeletions are the errors returned by the deletions of the resources
+	failingDeletions map[string]error
+	// describeErr is the error returned by the descriptions of the load balancers
+	describeErr error
+	operations  *[]string
+}
+
+var _ aws.ELBv2Client = &testELBv2Client{}
+
+func (c *testELBv2Client) DescribeLoadBalancers(_ context.Context, _ *elbv2.DescribeLoadBalancersInput, _ ...func(*elbv2.Options)) (*elbv2.DescribeLoadBalancersOutput, error) {
+	if c.describeErr != nil {
+		return nil, c.describeErr
+	}
+	return &elbv2.DescribeLoadBalancersOutput{LoadBalancers: c.loadBalancers}, nil
+}
+
+func (c *testELBv2Client) DescribeTargetGroups(_ context.Context, _ *elbv2.DescribeTargetGroupsInput, _ ...func(*elbv2.Options)) (*elbv2.DescribeTargetGroupsOutput, error) {
+	return &elbv2.DescribeTargetGroupsOutput{TargetGroups: c.targetGroups}, nil
+}
+
+func (c *testELBv2Client) DescribeTags(_ context.Context, input *elbv2.DescribeTagsInput, _ ...func(*elbv2.Options)) (*elbv2.DescribeTagsOutput, error) {
+	// DescribeTags accepts up to 20 resources
+	if len(input.ResourceArns) > 20 {
+		c.t.Errorf("too many resources in DescribeTags query: %d", len(input.ResourceArns))
+		return nil, badQueryError
+	}
+	output := &elbv2.DescribeTagsOutput{}
+	for _, arn := range input.ResourceArns {
+		description := elbv2types.TagDescription{ResourceArn: awstypes.String(arn)}
+		if clusterName, tagged := c.clusterTags[arn]; tagged {
+			description.Tags = []elbv2types.Tag{
+				{
+					Key:   awstypes.String(elbv2ClusterTagKey),
+					Value: awstypes.String(clusterName),
+				},
+			}
+		}
+		for key, value := range c.tags[arn] {
+			description.Tags = append(description.Tags, elbv2types.Tag{
+				Key:   awstypes.String(key),
+				Value: awstypes.String(value),
+			})
+		}
+		output.TagDescriptions = append(output.TagDescriptions, description)
+	}
+	return output, nil
+}
+
+func (c *testELBv2Client) DeleteLoadBalancer(_ context.Context, input *elbv2.DeleteLoadBalancerInput, _ ...func(*elbv2.Options)) (*elbv2.DeleteLoadBalancerOutput, error) {
+	return &elbv2.DeleteLoadBalancerOutput{}, c.delete("DeleteLoadBalancer", awstypes.ToString(input.LoadBalancerArn))
+}
+
+func (c *testELBv2Client) DeleteTargetGroup(_ context.Context, input *elbv2.DeleteTargetGroupInput, _ ...func(*elbv2.Options)) (*elbv2.DeleteTargetGroupOutput, error) {
+	return &elbv2.DeleteTargetGroupOutput{}, c.delete("DeleteTargetGroup", awstypes.ToString(input.TargetGroupArn))
+}
+
+func (c *testELBv2Client) delete(operation, arn string) error {
+	if err := c.failingDeletions[arn]; err != nil {
+		return err
+	}
+	*c.operations = append(*c.operations, fmt.Sprintf("%s %s", operation, arn))
+	return nil
+}
+
+// testSecurityGroupEC2Client is a fake EC2 client which records the deletions of the security groups in the given operations.
+type testSecurityGroupEC2Client struct {
+	*testEC2Client
+	securityGroups   []ec2types.SecurityGroup
+	failingDeletions map[string]error
+	operations       *[]string
+}
+
+func (c *testSecurityGroupEC2Client) DescribeSecurityGroups(_ context.Context, input *ec2.DescribeSecurityGroupsInput, _ ...func(*ec2.Options)) (*ec2.DescribeSecurityGroupsOutput, error) {
+	output := &ec2.DescribeSecurityGroupsOutput{}
+	for _, sg := range c.securityGroups {
+		matches := true
+		for _, filter := range input.Filters {
+			switch name := awstypes.ToString(filter.Name); name {
+			case vpcIDFilterName:
+				matches = matches && awstypes.ToString(sg.VpcId) == filter.Values[0]
+			case tagFilterNamePrefix + elbv2ClusterTagKey:
+				matches = matches && awstypes.ToString(sg.Tags[0].Value) == filter.Values[0]
+			default:
+				c.t.Errorf("unexpected filter %s", name)
+				return nil, badQueryError
+			}
+		}
+		if matches {
+			output.SecurityGroups = append(output.SecurityGroups, sg)
+		}
+	}
+	return output, nil
+}
+
+func (c *testSecurityGroupEC2Client) DeleteSecurityGroup(_ context.Context, input *ec2.DeleteSecurityGroupInput, _ ...func(*ec2.Options)) (*ec2.DeleteSecurityGroupOutput, error) {
+	if err := c.failingDeletions[awstypes.ToString(input.GroupId)]; err != nil {
+		return nil, err
+	}
+	*c.operations = append(*c.operations, fmt.Sprintf("DeleteSecurityGroup %s", awstypes.ToString(input.GroupId)))
+	return &ec2.DeleteSecurityGroupOutput{}, nil
+}
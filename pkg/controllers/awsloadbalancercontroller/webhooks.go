@@ -23,15 +23,83 @@ import (
 const (
 	injectCABundleAnnotationKey   = "service.beta.openshift.io/inject-cabundle"
 	injectCABundleAnnotationValue = "true"
+	// defaultWebhookTimeoutSeconds is the default timeout of the webhook calls, same as the API server's default.
+	defaultWebhookTimeoutSeconds = 10
+	// runLevelLabelKey is the label put on the OpenShift platform namespaces.
+	runLevelLabelKey = "openshift.io/run-level"
+	// podReadinessGateInjectLabelKey is the label of the namespaces whose pods get the target health readiness gates.
+	podReadinessGateInjectLabelKey = "elbv2.k8s.aws/pod-readiness-gate-inject"
+	// podReadinessGateInjectLabelValue is the value of podReadinessGateInjectLabelKey enabling the injection.
+	podReadinessGateInjectLabelValue = "enabled"
 )
 
+// platformNamespaces are the namespaces excluded from the webhooks by default.
+var platformNamespaces = []string{"kube-system", "kube-public", "kube-node-lease", "openshift"}
+
+// webhookSettings are the settings shared by all the webhooks of the controller.
+type webhookSettings struct {
+	failurePolicy     arv1.FailurePolicyType
+	timeoutSeconds    int32
+	namespaceSelector *metav1.LabelSelector
+	objectSelector    *metav1.LabelSelector
+	// admissionReviewVersions are the admission review versions supported by the operand.
+	admissionReviewVersions []string
+}
+
+// desiredWebhookSettings returns the webhook settings from the spec of the controller
+// falling back to the defaults for the unset fields.
+func desiredWebhookSettings(controller *albo.AWSLoadBalancerController, admissionReviewVersions []string) webhookSettings {
+	settings := webhookSettings{
+		failurePolicy:           arv1.Fail,
+		timeoutSeconds:          defaultWebhookTimeoutSeconds,
+		namespaceSelector:       defaultWebhookNamespaceSelector(),
+		admissionReviewVersions: admissionReviewVersions,
+	}
+	webhooks := controller.Spec.Webhooks
+	if webhooks == nil {
+		return settings
+	}
+	if webhooks.FailurePolicy == albo.IgnoreWebhookFailurePolicy {
+		settings.failurePolicy = arv1.Ignore
+	}
+	if webhooks.TimeoutSeconds != nil {
+		settings.timeoutSeconds = *webhooks.TimeoutSeconds
+	}
+	if webhooks.NamespaceSelector != nil {
+		settings.namespaceSelector = webhooks.NamespaceSelector
+	}
+	settings.objectSelector = webhooks.ObjectSelector
+	return settings
+}
+
+// defaultWebhookNamespaceSelector returns the namespace selector which excludes the platform namespaces.
+// A label selector cannot match the namespace names by prefix, the run-level label
+// is used to exclude the core OpenShift namespaces instead.
+func defaultWebhookNamespaceSelector() *metav1.LabelSelector {
+	return &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{
+				Key:      corev1.LabelMetadataName,
+				Operator: metav1.LabelSelectorOpNotIn,
+				Values:   platformNamespaces,
+			},
+			{
+				Key:      runLevelLabelKey,
+				Operator: metav1.LabelSelectorOpDoesNotExist,
+			},
+		},
+	}
+}
+
 // ensureWebhooks ensures that the ValidatingWebhookConfiguration and MutatingWebhookConfiguration resources associated with the controller
 // are created and up-to-date.
 func (r *AWSLoadBalancerControllerReconciler) ensureWebhooks(ctx context.Context, controller *albo.AWSLoadBalancerController, service *corev1.Service) error {
 	reqLogger := log.FromContext(ctx).WithValues("webhook", controller.Name)
 	reqLogger.Info("ensuring validating and mutating webhook configurations for aws-load-balancer-controller instance")
 
-	desiredVWC := desiredValidatingWebhookConfiguration(controller, service)
+	settings := desiredWebhookSettings(controller, r.desiredAdmissionReviewVersions())
+
+	desiredVWC := desiredValidatingWebhookConfiguration(controller, service, settings)
 	err := controllerutil.SetControllerReference(controller, desiredVWC, r.Scheme)
 	if err != nil {
 		return fmt.Errorf("failed to set owner reference on desired ValidatingWebhookConfiguration %q: %w", desiredVWC.Name, err)
@@ -55,7 +123,7 @@ func (r *AWSLoadBalancerControllerReconciler) ensureWebhooks(ctx context.Context
 		}
 	}
 
-	desiredMWC := desiredMutatingWebhookConfiguration(controller, service)
+	desiredMWC := desiredMutatingWebhookConfiguration(controller, service, settings)
 	err = controllerutil.SetControllerReference(controller, desiredMWC, r.Scheme)
 	if err != nil {
 		return fmt.Errorf("failed to set owner reference on desired MutatingWebhookConfiguration %q: %w", desiredMWC.Name, err)
@@ -100,7 +168,7 @@ func (r *AWSLoadBalancerControllerReconciler) currentMutatingWebhookConfiguratio
 	return &currentMWC, true, err
 }
 
-func desiredValidatingWebhookConfiguration(controller *albo.AWSLoadBalancerController, webhookService *corev1.Service) *arv1.ValidatingWebhookConfiguration {
+func desiredValidatingWebhookConfiguration(controller *albo.AWSLoadBalancerController, webhookService *corev1.Service, settings webhookSettings) *arv1.ValidatingWebhookConfiguration {
 	return &arv1.ValidatingWebhookConfiguration{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: fmt.Sprintf("%s-%s", controllerResourcePrefix, controller.Name),
@@ -133,10 +201,13 @@ func desiredValidatingWebhookConfiguration(controller *albo.AWSLoadBalancerContr
 						},
 					},
 				},
-				FailurePolicy:           failurePolicyPtr(arv1.Fail),
+				FailurePolicy:           failurePolicyPtr(settings.failurePolicy),
+				TimeoutSeconds:          ptr.To[int32](settings.timeoutSeconds),
+				NamespaceSelector:       settings.namespaceSelector.DeepCopy(),
+				ObjectSelector:          settings.objectSelector.DeepCopy(),
 				MatchPolicy:             matchPolicyPtr(arv1.Equivalent),
 				SideEffects:             sideEffectPtr(arv1.SideEffectClassNone),
-				AdmissionReviewVersions: []string{"v1beta1"},
+				AdmissionReviewVersions: append([]string(nil), settings.admissionReviewVersions...),
 			},
 			{
 				Name: "vingress.elbv2.k8s.aws",
@@ -162,10 +233,13 @@ func desiredValidatingWebhookConfiguration(controller *albo.AWSLoadBalancerContr
 						},
 					},
 				},
-				FailurePolicy:           failurePolicyPtr(arv1.Fail),
+				FailurePolicy:           failurePolicyPtr(settings.failurePolicy),
+				TimeoutSeconds:          ptr.To[int32](settings.timeoutSeconds),
+				NamespaceSelector:       settings.namespaceSelector.DeepCopy(),
+				ObjectSelector:          settings.objectSelector.DeepCopy(),
 				MatchPolicy:             matchPolicyPtr(arv1.Equivalent),
 				SideEffects:             sideEffectPtr(arv1.SideEffectClassNone),
-				AdmissionReviewVersions: []string{"v1beta1"},
+				AdmissionReviewVersions: append([]string(nil), settings.admissionReviewVersions...),
 			},
 		},
 	}
@@ -279,6 +353,20 @@ func haveValidatingWebhooksChanged(updated sortableValidatingWebhooks, desired s
 				return true
 			}
 		}
+		if d.TimeoutSeconds != nil {
+			if u.TimeoutSeconds == nil {
+				return true
+			}
+			if *u.TimeoutSeconds != *d.TimeoutSeconds {
+				return true
+			}
+		}
+		if !equalLabelSelectors(u.NamespaceSelector, d.NamespaceSelector) {
+			return true
+		}
+		if !equalLabelSelectors(u.ObjectSelector, d.ObjectSelector) {
+			return true
+		}
 		if d.MatchPolicy != nil {
 			if u.MatchPolicy == nil {
 				return true
@@ -321,8 +409,8 @@ func rulesEqual(desired arv1.RuleWithOperations, current arv1.RuleWithOperations
 	return true
 }
 
-func desiredMutatingWebhookConfiguration(controller *albo.AWSLoadBalancerController, webhookService *corev1.Service) *arv1.MutatingWebhookConfiguration {
-	return &arv1.MutatingWebhookConfiguration{
+func desiredMutatingWebhookConfiguration(controller *albo.AWSLoadBalancerController, webhookService *corev1.Service, settings webhookSettings) *arv1.MutatingWebhookConfiguration {
+	mwc := &arv1.MutatingWebhookConfiguration{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: fmt.Sprintf("%s-%s", controllerResourcePrefix, controller.Name),
 			Annotations: map[string]string{
@@ -331,7 +419,7 @@ func desiredMutatingWebhookConfiguration(controller *albo.AWSLoadBalancerControl
 		},
 		Webhooks: []arv1.MutatingWebhook{
 			{
-				AdmissionReviewVersions: []string{"v1beta1"},
+				AdmissionReviewVersions: append([]string(nil), settings.admissionReviewVersions...),
 				ClientConfig: arv1.WebhookClientConfig{
 					Service: &arv1.ServiceReference{Name: webhookService.Name,
 						Namespace: webhookService.Namespace,
@@ -339,8 +427,11 @@ func desiredMutatingWebhookConfiguration(controller *albo.AWSLoadBalancerControl
 						Port:      ptr.To[int32](controllerWebhookPort),
 					},
 				},
-				FailurePolicy: failurePolicyPtr(arv1.Fail),
-				Name:          "mtargetgroupbinding.elbv2.k8s.aws",
+				FailurePolicy:     failurePolicyPtr(settings.failurePolicy),
+				TimeoutSeconds:    ptr.To[int32](settings.timeoutSeconds),
+				NamespaceSelector: settings.namespaceSelector.DeepCopy(),
+				ObjectSelector:    settings.objectSelector.DeepCopy(),
+				Name:              "mtargetgroupbinding.elbv2.k8s.aws",
 				Rules: []arv1.RuleWithOperations{
 					{
 						Rule: arv1.Rule{
@@ -359,6 +450,106 @@ func desiredMutatingWebhookConfiguration(controller *albo.AWSLoadBalancerControl
 			},
 		},
 	}
+	if ipTargetTypeEnabled(controller) {
+		mwc.Webhooks = append(mwc.Webhooks, desiredPodMutatingWebhook(webhookService, settings), desiredServiceMutatingWebhook(webhookService, settings))
+	}
+	return mwc
+}
+
+// ipTargetNamespaceSelector returns the selector of the namespaces which opted in for the readiness gates.
+func ipTargetNamespaceSelector() *metav1.LabelSelector {
+	return &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{
+				Key:      podReadinessGateInjectLabelKey,
+				Operator: metav1.LabelSelectorOpIn,
+				Values:   []string{podReadinessGateInjectLabelValue},
+			},
+		},
+	}
+}
+
+// nonControllerObjectSelector returns the selector which excludes the resources of the controller.
+func nonControllerObjectSelector() *metav1.LabelSelector {
+	return &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{
+				Key:      appLabelName,
+				Operator: metav1.LabelSelectorOpNotIn,
+				Values:   []string{appName},
+			},
+		},
+	}
+}
+
+// desiredPodMutatingWebhook returns the webhook which injects the readiness gates into the pods
+// registered as IP targets. Only the namespaces which opted in for the readiness gates are selected.
+// The controller pods are excluded to let them start when the webhook cannot be called.
+func desiredPodMutatingWebhook(webhookService *corev1.Service, settings webhookSettings) arv1.MutatingWebhook {
+	return arv1.MutatingWebhook{
+		AdmissionReviewVersions: append([]string(nil), settings.admissionReviewVersions...),
+		ClientConfig: arv1.WebhookClientConfig{
+			Service: &arv1.ServiceReference{Name: webhookService.Name,
+				Namespace: webhookService.Namespace,
+				Path:      ptr.To[string]("/mutate-v1-pod"),
+				Port:      ptr.To[int32](controllerWebhookPort),
+			},
+		},
+		FailurePolicy:     failurePolicyPtr(settings.failurePolicy),
+		TimeoutSeconds:    ptr.To[int32](settings.timeoutSeconds),
+		NamespaceSelector: ipTargetNamespaceSelector(),
+		ObjectSelector:    nonControllerObjectSelector(),
+		Name:              "mpod.elbv2.k8s.aws",
+		Rules: []arv1.RuleWithOperations{
+			{
+				Rule: arv1.Rule{
+					APIGroups:   []string{""},
+					APIVersions: []string{"v1"},
+					Resources:   []string{"pods"},
+					Scope:       scopeTypePtr(arv1.NamespacedScope),
+				},
+				Operations: []arv1.OperationType{
+					arv1.Create,
+				},
+			},
+		},
+		SideEffects: sideEffectPtr(arv1.SideEffectClassNone),
+	}
+}
+
+// desiredServiceMutatingWebhook returns the webhook which defaults the Services of the load balancers targeting the pods.
+// Like the pod webhook, only the namespaces which opted in for the readiness gates are selected
+// and the controller service is excluded.
+func desiredServiceMutatingWebhook(webhookService *corev1.Service, settings webhookSettings) arv1.MutatingWebhook {
+	return arv1.MutatingWebhook{
+		AdmissionReviewVersions: append([]string(nil), settings.admissionReviewVersions...),
+		ClientConfig: arv1.WebhookClientConfig{
+			Service: &arv1.ServiceReference{Name: webhookService.Name,
+				Namespace: webhookService.Namespace,
+				Path:      ptr.To[string]("/mutate-v1-service"),
+				Port:      ptr.To[int32](controllerWebhookPort),
+			},
+		},
+		FailurePolicy:     failurePolicyPtr(settings.failurePolicy),
+		TimeoutSeconds:    ptr.To[int32](settings.timeoutSeconds),
+		NamespaceSelector: ipTargetNamespaceSelector(),
+		ObjectSelector:    nonControllerObjectSelector(),
+		Name:              "mservice.elbv2.k8s.aws",
+		Rules: []arv1.RuleWithOperations{
+			{
+				Rule: arv1.Rule{
+					APIGroups:   []string{""},
+					APIVersions: []string{"v1"},
+					Resources:   []string{"services"},
+					Scope:       scopeTypePtr(arv1.NamespacedScope),
+				},
+				Operations: []arv1.OperationType{
+					arv1.Create,
+				},
+			},
+		},
+		SideEffects: sideEffectPtr(arv1.SideEffectClassNone),
+	}
 }
 
 func (r *AWSLoadBalancerControllerReconciler) updateMutatingWebhookConfiguration(ctx context.Context, current, desired *arv1.MutatingWebhookConfiguration) error {
@@ -425,6 +616,20 @@ func haveMutatingWebhooksChanged(updated, desired sortableMutatingWebhooks) bool
 				return true
 			}
 		}
+		if d.TimeoutSeconds != nil {
+			if u.TimeoutSeconds == nil {
+				return true
+			}
+			if *u.TimeoutSeconds != *d.TimeoutSeconds {
+				return true
+			}
+		}
+		if !equalLabelSelectors(u.NamespaceSelector, d.NamespaceSelector) {
+			return true
+		}
+		if !equalLabelSelectors(u.ObjectSelector, d.ObjectSelector) {
+			return true
+		}
 		if d.MatchPolicy != nil {
 			if u.MatchPolicy == nil {
 				return true
@@ -448,6 +653,18 @@ func haveMutatingWebhooksChanged(updated, desired sortableMutatingWebhooks) bool
 	return false
 }
 
+// equalLabelSelectors indicates if the label selectors are the same.
+// The API server defaults the webhook selectors to the empty selector, nil is equal to the empty selector.
+func equalLabelSelectors(current, desired *metav1.LabelSelector) bool {
+	if current == nil {
+		current = &metav1.LabelSelector{}
+	}
+	if desired == nil {
+		desired = &metav1.LabelSelector{}
+	}
+	return equality.Semantic.DeepEqual(current, desired)
+}
+
 func scopeTypePtr(scopeType arv1.ScopeType) *arv1.ScopeType {
 	return &scopeType
 }
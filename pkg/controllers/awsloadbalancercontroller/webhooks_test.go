@@ -9,6 +9,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -188,6 +189,41 @@ func TestAreValidatingWebhooksSame(t *testing.T) {
 				},
 			}}},
 		},
+		{
+			name:           "timeout changed",
+			currentVWs:     []arv1.ValidatingWebhook{{Name: "a", TimeoutSeconds: ptr.To[int32](10)}},
+			desiredVWs:     []arv1.ValidatingWebhook{{Name: "a", TimeoutSeconds: ptr.To[int32](5)}},
+			expectedResult: true,
+		},
+		{
+			name:           "namespace selector changed",
+			currentVWs:     []arv1.ValidatingWebhook{{Name: "a", NamespaceSelector: &metav1.LabelSelector{}}},
+			desiredVWs:     []arv1.ValidatingWebhook{{Name: "a", NamespaceSelector: defaultWebhookNamespaceSelector()}},
+			expectedResult: true,
+		},
+		{
+			name:       "empty namespace selector defaulted",
+			currentVWs: []arv1.ValidatingWebhook{{Name: "a", NamespaceSelector: &metav1.LabelSelector{}}},
+			desiredVWs: []arv1.ValidatingWebhook{{Name: "a"}},
+		},
+		{
+			name:           "object selector changed",
+			currentVWs:     []arv1.ValidatingWebhook{{Name: "a", ObjectSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"alb": "true"}}}},
+			desiredVWs:     []arv1.ValidatingWebhook{{Name: "a", ObjectSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"alb": "false"}}}},
+			expectedResult: true,
+		},
+		{
+			name:           "admission review v1 added",
+			currentVWs:     []arv1.ValidatingWebhook{{Name: "a", AdmissionReviewVersions: []string{"v1beta1"}}},
+			desiredVWs:     []arv1.ValidatingWebhook{{Name: "a", AdmissionReviewVersions: []string{"v1", "v1beta1"}}},
+			expectedResult: true,
+		},
+		{
+			name:           "admission review versions preference changed",
+			currentVWs:     []arv1.ValidatingWebhook{{Name: "a", AdmissionReviewVersions: []string{"v1beta1", "v1"}}},
+			desiredVWs:     []arv1.ValidatingWebhook{{Name: "a", AdmissionReviewVersions: []string{"v1", "v1beta1"}}},
+			expectedResult: true,
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			result := haveValidatingWebhooksChanged(tc.currentVWs, tc.desiredVWs)
@@ -369,6 +405,41 @@ func TestAreMutatingWebhooksSame(t *testing.T) {
 				},
 			}}},
 		},
+		{
+			name:           "timeout changed",
+			currentVWs:     []arv1.MutatingWebhook{{Name: "a", TimeoutSeconds: ptr.To[int32](10)}},
+			desiredVWs:     []arv1.MutatingWebhook{{Name: "a", TimeoutSeconds: ptr.To[int32](5)}},
+			expectedResult: true,
+		},
+		{
+			name:           "namespace selector changed",
+			currentVWs:     []arv1.MutatingWebhook{{Name: "a", NamespaceSelector: &metav1.LabelSelector{}}},
+			desiredVWs:     []arv1.MutatingWebhook{{Name: "a", NamespaceSelector: defaultWebhookNamespaceSelector()}},
+			expectedResult: true,
+		},
+		{
+			name:       "empty namespace selector defaulted",
+			currentVWs: []arv1.MutatingWebhook{{Name: "a", NamespaceSelector: &metav1.LabelSelector{}}},
+			desiredVWs: []arv1.MutatingWebhook{{Name: "a"}},
+		},
+		{
+			name:           "object selector changed",
+			currentVWs:     []arv1.MutatingWebhook{{Name: "a", ObjectSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"alb": "true"}}}},
+			desiredVWs:     []arv1.MutatingWebhook{{Name: "a", ObjectSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"alb": "false"}}}},
+			expectedResult: true,
+		},
+		{
+			name:           "admission review v1 added",
+			currentVWs:     []arv1.MutatingWebhook{{Name: "a", AdmissionReviewVersions: []string{"v1beta1"}}},
+			desiredVWs:     []arv1.MutatingWebhook{{Name: "a", AdmissionReviewVersions: []string{"v1", "v1beta1"}}},
+			expectedResult: true,
+		},
+		{
+			name:           "admission review versions preference changed",
+			currentVWs:     []arv1.MutatingWebhook{{Name: "a", AdmissionReviewVersions: []string{"v1beta1", "v1"}}},
+			desiredVWs:     []arv1.MutatingWebhook{{Name: "a", AdmissionReviewVersions: []string{"v1", "v1beta1"}}},
+			expectedResult: true,
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			result := haveMutatingWebhooksChanged(tc.currentVWs, tc.desiredVWs)
@@ -380,6 +451,10 @@ func TestAreMutatingWebhooksSame(t *testing.T) {
 }
 
 func testValidatingWebhooks(serviceName, serviceNamespace string) []arv1.ValidatingWebhook {
+	return testValidatingWebhooksWithSettings(serviceName, serviceNamespace, arv1.Fail, 10, defaultWebhookNamespaceSelector(), nil, []string{"v1", "v1beta1"})
+}
+
+func testValidatingWebhooksWithSettings(serviceName, serviceNamespace string, failurePolicy arv1.FailurePolicyType, timeoutSeconds int32, namespaceSelector, objectSelector *metav1.LabelSelector, admissionReviewVersions []string) []arv1.ValidatingWebhook {
 	return []arv1.ValidatingWebhook{
 		{
 			Name: "vtargetgroupbinding.elbv2.k8s.aws",
@@ -405,10 +480,13 @@ func testValidatingWebhooks(serviceName, serviceNamespace string) []arv1.Validat
 					},
 				},
 			},
-			FailurePolicy:           failurePolicyPtr(arv1.Fail),
+			FailurePolicy:           failurePolicyPtr(failurePolicy),
+			TimeoutSeconds:          ptr.To[int32](timeoutSeconds),
+			NamespaceSelector:       namespaceSelector,
+			ObjectSelector:          objectSelector,
 			MatchPolicy:             matchPolicyPtr(arv1.Equivalent),
 			SideEffects:             sideEffectPtr(arv1.SideEffectClassNone),
-			AdmissionReviewVersions: []string{"v1beta1"},
+			AdmissionReviewVersions: admissionReviewVersions,
 		},
 		{
 			Name: "vingress.elbv2.k8s.aws",
@@ -434,18 +512,25 @@ func testValidatingWebhooks(serviceName, serviceNamespace string) []arv1.Validat
 					},
 				},
 			},
-			FailurePolicy:           failurePolicyPtr(arv1.Fail),
+			FailurePolicy:           failurePolicyPtr(failurePolicy),
+			TimeoutSeconds:          ptr.To[int32](timeoutSeconds),
+			NamespaceSelector:       namespaceSelector,
+			ObjectSelector:          objectSelector,
 			MatchPolicy:             matchPolicyPtr(arv1.Equivalent),
 			SideEffects:             sideEffectPtr(arv1.SideEffectClassNone),
-			AdmissionReviewVersions: []string{"v1beta1"},
+			AdmissionReviewVersions: admissionReviewVersions,
 		},
 	}
 }
 
 func testMutatingWebhooks(serviceName, serviceNamespace string) []arv1.MutatingWebhook {
+	return testMutatingWebhooksWithSettings(serviceName, serviceNamespace, arv1.Fail, 10, defaultWebhookNamespaceSelector(), nil, []string{"v1", "v1beta1"})
+}
+
+func testMutatingWebhooksWithSettings(serviceName, serviceNamespace string, failurePolicy arv1.FailurePolicyType, timeoutSeconds int32, namespaceSelector, objectSelector *metav1.LabelSelector, admissionReviewVersions []string) []arv1.MutatingWebhook {
 	return []arv1.MutatingWebhook{
 		{
-			AdmissionReviewVersions: []string{"v1beta1"},
+			AdmissionReviewVersions: admissionReviewVersions,
 			ClientConfig: arv1.WebhookClientConfig{
 				Service: &arv1.ServiceReference{Name: serviceName,
 					Namespace: serviceNamespace,
@@ -453,8 +538,11 @@ func testMutatingWebhooks(serviceName, serviceNamespace string) []arv1.MutatingW
 					Port:      ptr.To[int32](controllerWebhookPort),
 				},
 			},
-			FailurePolicy: failurePolicyPtr(arv1.Fail),
-			Name:          "mtargetgroupbinding.elbv2.k8s.aws",
+			FailurePolicy:     failurePolicyPtr(failurePolicy),
+			TimeoutSeconds:    ptr.To[int32](timeoutSeconds),
+			NamespaceSelector: namespaceSelector,
+			ObjectSelector:    objectSelector,
+			Name:              "mtargetgroupbinding.elbv2.k8s.aws",
 			Rules: []arv1.RuleWithOperations{
 				{
 					Rule: arv1.Rule{
@@ -474,6 +562,59 @@ func testMutatingWebhooks(serviceName, serviceNamespace string) []arv1.MutatingW
 	}
 }
 
+func testPodMutatingWebhook(serviceName, serviceNamespace string) arv1.MutatingWebhook {
+	return arv1.MutatingWebhook{
+		AdmissionReviewVersions: []string{"v1", "v1beta1"},
+		ClientConfig: arv1.WebhookClientConfig{
+			Service: &arv1.ServiceReference{Name: serviceName,
+				Namespace: serviceNamespace,
+				Path:      ptr.To[string]("/mutate-v1-pod"),
+				Port:      ptr.To[int32](controllerWebhookPort),
+			},
+		},
+		FailurePolicy:  failurePolicyPtr(arv1.Fail),
+		TimeoutSeconds: ptr.To[int32](10),
+		NamespaceSelector: &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "elbv2.k8s.aws/pod-readiness-gate-inject", Operator: metav1.LabelSelectorOpIn, Values: []string{"enabled"}},
+			},
+		},
+		ObjectSelector: &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "app.kubernetes.io/name", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"aws-load-balancer-operator"}},
+			},
+		},
+		Name: "mpod.elbv2.k8s.aws",
+		Rules: []arv1.RuleWithOperations{
+			{
+				Rule: arv1.Rule{
+					APIGroups:   []string{""},
+					APIVersions: []string{"v1"},
+					Resources:   []string{"pods"},
+					Scope:       scopeTypePtr(arv1.NamespacedScope),
+				},
+				Operations: []arv1.OperationType{
+					arv1.Create,
+				},
+			},
+		},
+		SideEffects: sideEffectPtr(arv1.SideEffectClassNone),
+	}
+}
+
+func testServiceMutatingWebhook(serviceName, serviceNamespace string) arv1.MutatingWebhook {
+	webhook := testPodMutatingWebhook(serviceName, serviceNamespace)
+	webhook.Name = "mservice.elbv2.k8s.aws"
+	webhook.ClientConfig.Service.Path = ptr.To[string]("/mutate-v1-service")
+	webhook.Rules[0].Resources = []string{"services"}
+	return webhook
+}
+
+func testV1beta1MutatingWebhook(webhook arv1.MutatingWebhook) arv1.MutatingWebhook {
+	webhook.AdmissionReviewVersions = []string{"v1beta1"}
+	return webhook
+}
+
 func TestEnsureWebhooks(t *testing.T) {
 	for _, tc := range []struct {
 		name            string
@@ -482,9 +623,11 @@ func TestEnsureWebhooks(t *testing.T) {
 		expectedVWC     *arv1.ValidatingWebhookConfiguration
 		expectedMWC     *arv1.MutatingWebhookConfiguration
 		existingObjects []client.Object
+		operandVersion  *version.Version
 	}{
 		{
 			name:           "no existing webhooks",
+			operandVersion: version.MustParseSemantic("v2.8.2"),
 			controller:     &albo.AWSLoadBalancerController{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}},
 			webhookService: &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "test-service", Namespace: "test-namespace"}},
 			expectedVWC: &arv1.ValidatingWebhookConfiguration{
@@ -503,8 +646,9 @@ func TestEnsureWebhooks(t *testing.T) {
 			},
 		},
 		{
-			name:       "existing validating webhook",
-			controller: &albo.AWSLoadBalancerController{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}},
+			name:           "existing validating webhook",
+			operandVersion: version.MustParseSemantic("v2.8.2"),
+			controller:     &albo.AWSLoadBalancerController{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}},
 			existingObjects: []client.Object{
 				&arv1.ValidatingWebhookConfiguration{
 					ObjectMeta: metav1.ObjectMeta{
@@ -535,8 +679,9 @@ func TestEnsureWebhooks(t *testing.T) {
 			},
 		},
 		{
-			name:       "existing mutating webhook",
-			controller: &albo.AWSLoadBalancerController{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}},
+			name:           "existing mutating webhook",
+			operandVersion: version.MustParseSemantic("v2.8.2"),
+			controller:     &albo.AWSLoadBalancerController{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}},
 			existingObjects: []client.Object{
 				&arv1.MutatingWebhookConfiguration{
 					ObjectMeta: metav1.ObjectMeta{
@@ -567,8 +712,9 @@ func TestEnsureWebhooks(t *testing.T) {
 			},
 		},
 		{
-			name:       "existing webhooks with third-party annotations",
-			controller: &albo.AWSLoadBalancerController{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}},
+			name:           "existing webhooks with third-party annotations",
+			operandVersion: version.MustParseSemantic("v2.8.2"),
+			controller:     &albo.AWSLoadBalancerController{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}},
 			existingObjects: []client.Object{
 				&arv1.MutatingWebhookConfiguration{
 					ObjectMeta: metav1.ObjectMeta{
@@ -619,13 +765,318 @@ func TestEnsureWebhooks(t *testing.T) {
 				Webhooks: testMutatingWebhooks("test-service", "test-namespace"),
 			},
 		},
+		{
+			name:           "webhooks settings changed",
+			operandVersion: version.MustParseSemantic("v2.8.2"),
+			controller: &albo.AWSLoadBalancerController{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Spec: albo.AWSLoadBalancerControllerSpec{
+					Webhooks: &albo.AWSLoadBalancerControllerWebhooks{
+						FailurePolicy:     albo.IgnoreWebhookFailurePolicy,
+						TimeoutSeconds:    ptr.To[int32](5),
+						NamespaceSelector: &metav1.LabelSelector{},
+						ObjectSelector:    &metav1.LabelSelector{MatchLabels: map[string]string{"alb": "true"}},
+					},
+				},
+			},
+			existingObjects: []client.Object{
+				&arv1.ValidatingWebhookConfiguration{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "aws-load-balancer-controller-cluster",
+						Annotations: map[string]string{injectCABundleAnnotationKey: injectCABundleAnnotationValue},
+						OwnerReferences: []metav1.OwnerReference{
+							{Name: "cluster", Kind: "AWSLoadBalancerController"},
+						},
+					},
+					Webhooks: testValidatingWebhooks("test-service", "test-namespace"),
+				},
+				&arv1.MutatingWebhookConfiguration{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "aws-load-balancer-controller-cluster",
+						Annotations: map[string]string{injectCABundleAnnotationKey: injectCABundleAnnotationValue},
+						OwnerReferences: []metav1.OwnerReference{
+							{Name: "cluster", Kind: "AWSLoadBalancerController"},
+						},
+					},
+					Webhooks: testMutatingWebhooks("test-service", "test-namespace"),
+				},
+			},
+			webhookService: &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "test-service", Namespace: "test-namespace"}},
+			expectedVWC: &arv1.ValidatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "aws-load-balancer-controller-cluster",
+					Annotations: map[string]string{injectCABundleAnnotationKey: injectCABundleAnnotationValue},
+				},
+				Webhooks: testValidatingWebhooksWithSettings("test-service", "test-namespace", arv1.Ignore, 5, &metav1.LabelSelector{}, &metav1.LabelSelector{MatchLabels: map[string]string{"alb": "true"}}, []string{"v1", "v1beta1"}),
+			},
+			expectedMWC: &arv1.MutatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "aws-load-balancer-controller-cluster",
+					Annotations: map[string]string{injectCABundleAnnotationKey: injectCABundleAnnotationValue},
+				},
+				Webhooks: testMutatingWebhooksWithSettings("test-service", "test-namespace", arv1.Ignore, 5, &metav1.LabelSelector{}, &metav1.LabelSelector{MatchLabels: map[string]string{"alb": "true"}}, []string{"v1", "v1beta1"}),
+			},
+		},
+		{
+			name:           "operand without admission review v1",
+			controller:     &albo.AWSLoadBalancerController{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}},
+			webhookService: &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "test-service", Namespace: "test-namespace"}},
+			operandVersion: version.MustParseSemantic("v2.3.1"),
+			expectedVWC: &arv1.ValidatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "aws-load-balancer-controller-cluster",
+					Annotations: map[string]string{injectCABundleAnnotationKey: injectCABundleAnnotationValue},
+				},
+				Webhooks: testValidatingWebhooksWithSettings("test-service", "test-namespace", arv1.Fail, 10, defaultWebhookNamespaceSelector(), nil, []string{"v1beta1"}),
+			},
+			expectedMWC: &arv1.MutatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "aws-load-balancer-controller-cluster",
+					Annotations: map[string]string{injectCABundleAnnotationKey: injectCABundleAnnotationValue},
+				},
+				Webhooks: testMutatingWebhooksWithSettings("test-service", "test-namespace", arv1.Fail, 10, defaultWebhookNamespaceSelector(), nil, []string{"v1beta1"}),
+			},
+		},
+		{
+			name:       "operand upgraded to admission review v1",
+			controller: &albo.AWSLoadBalancerController{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}},
+			existingObjects: []client.Object{
+				&arv1.ValidatingWebhookConfiguration{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "aws-load-balancer-controller-cluster",
+						Annotations: map[string]string{injectCABundleAnnotationKey: injectCABundleAnnotationValue},
+						OwnerReferences: []metav1.OwnerReference{
+							{Name: "cluster", Kind: "AWSLoadBalancerController"},
+						},
+					},
+					Webhooks: testValidatingWebhooksWithSettings("test-service", "test-namespace", arv1.Fail, 10, defaultWebhookNamespaceSelector(), nil, []string{"v1beta1"}),
+				},
+				&arv1.MutatingWebhookConfiguration{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "aws-load-balancer-controller-cluster",
+						Annotations: map[string]string{injectCABundleAnnotationKey: injectCABundleAnnotationValue},
+						OwnerReferences: []metav1.OwnerReference{
+							{Name: "cluster", Kind: "AWSLoadBalancerController"},
+						},
+					},
+					Webhooks: testMutatingWebhooksWithSettings("test-service", "test-namespace", arv1.Fail, 10, defaultWebhookNamespaceSelector(), nil, []string{"v1beta1"}),
+				},
+			},
+			webhookService: &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "test-service", Namespace: "test-namespace"}},
+			operandVersion: version.MustParseSemantic("v2.8.2"),
+			expectedVWC: &arv1.ValidatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "aws-load-balancer-controller-cluster",
+					Annotations: map[string]string{injectCABundleAnnotationKey: injectCABundleAnnotationValue},
+				},
+				Webhooks: testValidatingWebhooks("test-service", "test-namespace"),
+			},
+			expectedMWC: &arv1.MutatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "aws-load-balancer-controller-cluster",
+					Annotations: map[string]string{injectCABundleAnnotationKey: injectCABundleAnnotationValue},
+				},
+				Webhooks: testMutatingWebhooks("test-service", "test-namespace"),
+			},
+		},
+		{
+			name:       "operand downgraded below admission review v1",
+			controller: &albo.AWSLoadBalancerController{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}},
+			existingObjects: []client.Object{
+				&arv1.ValidatingWebhookConfiguration{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "aws-load-balancer-controller-cluster",
+						Annotations: map[string]string{injectCABundleAnnotationKey: injectCABundleAnnotationValue},
+						OwnerReferences: []metav1.OwnerReference{
+							{Name: "cluster", Kind: "AWSLoadBalancerController"},
+						},
+					},
+					Webhooks: testValidatingWebhooks("test-service", "test-namespace"),
+				},
+				&arv1.MutatingWebhookConfiguration{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "aws-load-balancer-controller-cluster",
+						Annotations: map[string]string{injectCABundleAnnotationKey: injectCABundleAnnotationValue},
+						OwnerReferences: []metav1.OwnerReference{
+							{Name: "cluster", Kind: "AWSLoadBalancerController"},
+						},
+					},
+					Webhooks: testMutatingWebhooks("test-service", "test-namespace"),
+				},
+			},
+			webhookService: &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "test-service", Namespace: "test-namespace"}},
+			operandVersion: version.MustParseSemantic("v2.3.1"),
+			expectedVWC: &arv1.ValidatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "aws-load-balancer-controller-cluster",
+					Annotations: map[string]string{injectCABundleAnnotationKey: injectCABundleAnnotationValue},
+				},
+				Webhooks: testValidatingWebhooksWithSettings("test-service", "test-namespace", arv1.Fail, 10, defaultWebhookNamespaceSelector(), nil, []string{"v1beta1"}),
+			},
+			expectedMWC: &arv1.MutatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "aws-load-balancer-controller-cluster",
+					Annotations: map[string]string{injectCABundleAnnotationKey: injectCABundleAnnotationValue},
+				},
+				Webhooks: testMutatingWebhooksWithSettings("test-service", "test-namespace", arv1.Fail, 10, defaultWebhookNamespaceSelector(), nil, []string{"v1beta1"}),
+			},
+		},
+		{
+			name:           "operand of unknown version",
+			controller:     &albo.AWSLoadBalancerController{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}},
+			webhookService: &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "test-service", Namespace: "test-namespace"}},
+			expectedVWC: &arv1.ValidatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "aws-load-balancer-controller-cluster",
+					Annotations: map[string]string{injectCABundleAnnotationKey: injectCABundleAnnotationValue},
+				},
+				Webhooks: testValidatingWebhooksWithSettings("test-service", "test-namespace", arv1.Fail, 10, defaultWebhookNamespaceSelector(), nil, []string{"v1beta1"}),
+			},
+			expectedMWC: &arv1.MutatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "aws-load-balancer-controller-cluster",
+					Annotations: map[string]string{injectCABundleAnnotationKey: injectCABundleAnnotationValue},
+				},
+				Webhooks: testMutatingWebhooksWithSettings("test-service", "test-namespace", arv1.Fail, 10, defaultWebhookNamespaceSelector(), nil, []string{"v1beta1"}),
+			},
+		},
+		{
+			name:           "ip target type enabled",
+			operandVersion: version.MustParseSemantic("v2.8.2"),
+			controller: &albo.AWSLoadBalancerController{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Spec:       albo.AWSLoadBalancerControllerSpec{TargetType: albo.IPTargetType},
+			},
+			existingObjects: []client.Object{
+				&arv1.ValidatingWebhookConfiguration{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "aws-load-balancer-controller-cluster",
+						Annotations: map[string]string{injectCABundleAnnotationKey: injectCABundleAnnotationValue},
+						OwnerReferences: []metav1.OwnerReference{
+							{Name: "cluster", Kind: "AWSLoadBalancerController"},
+						},
+					},
+					Webhooks: testValidatingWebhooks("test-service", "test-namespace"),
+				},
+				&arv1.MutatingWebhookConfiguration{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "aws-load-balancer-controller-cluster",
+						Annotations: map[string]string{injectCABundleAnnotationKey: injectCABundleAnnotationValue},
+						OwnerReferences: []metav1.OwnerReference{
+							{Name: "cluster", Kind: "AWSLoadBalancerController"},
+						},
+					},
+					Webhooks: testMutatingWebhooks("test-service", "test-namespace"),
+				},
+			},
+			webhookService: &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "test-service", Namespace: "test-namespace"}},
+			expectedVWC: &arv1.ValidatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "aws-load-balancer-controller-cluster",
+					Annotations: map[string]string{injectCABundleAnnotationKey: injectCABundleAnnotationValue},
+				},
+				Webhooks: testValidatingWebhooks("test-service", "test-namespace"),
+			},
+			expectedMWC: &arv1.MutatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "aws-load-balancer-controller-cluster",
+					Annotations: map[string]string{injectCABundleAnnotationKey: injectCABundleAnnotationValue},
+				},
+				Webhooks: append(testMutatingWebhooks("test-service", "test-namespace"), testPodMutatingWebhook("test-service", "test-namespace"), testServiceMutatingWebhook("test-service", "test-namespace")),
+			},
+		},
+		{
+			name: "drifted service webhook repaired for operand without admission review v1",
+			controller: &albo.AWSLoadBalancerController{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Spec:       albo.AWSLoadBalancerControllerSpec{TargetType: albo.IPTargetType},
+			},
+			existingObjects: []client.Object{
+				&arv1.MutatingWebhookConfiguration{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "aws-load-balancer-controller-cluster",
+						Annotations: map[string]string{injectCABundleAnnotationKey: injectCABundleAnnotationValue},
+						OwnerReferences: []metav1.OwnerReference{
+							{Name: "cluster", Kind: "AWSLoadBalancerController"},
+						},
+					},
+					Webhooks: append(testMutatingWebhooksWithSettings("test-service", "test-namespace", arv1.Fail, 10, defaultWebhookNamespaceSelector(), nil, []string{"v1beta1"}),
+						testV1beta1MutatingWebhook(testPodMutatingWebhook("test-service", "test-namespace")),
+						arv1.MutatingWebhook{Name: "mservice.elbv2.k8s.aws"}),
+				},
+			},
+			webhookService: &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "test-service", Namespace: "test-namespace"}},
+			operandVersion: version.MustParseSemantic("v2.3.1"),
+			expectedVWC: &arv1.ValidatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "aws-load-balancer-controller-cluster",
+					Annotations: map[string]string{injectCABundleAnnotationKey: injectCABundleAnnotationValue},
+				},
+				Webhooks: testValidatingWebhooksWithSettings("test-service", "test-namespace", arv1.Fail, 10, defaultWebhookNamespaceSelector(), nil, []string{"v1beta1"}),
+			},
+			expectedMWC: &arv1.MutatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "aws-load-balancer-controller-cluster",
+					Annotations: map[string]string{injectCABundleAnnotationKey: injectCABundleAnnotationValue},
+				},
+				Webhooks: append(testMutatingWebhooksWithSettings("test-service", "test-namespace", arv1.Fail, 10, defaultWebhookNamespaceSelector(), nil, []string{"v1beta1"}),
+					testV1beta1MutatingWebhook(testPodMutatingWebhook("test-service", "test-namespace")),
+					testV1beta1MutatingWebhook(testServiceMutatingWebhook("test-service", "test-namespace"))),
+			},
+		},
+		{
+			name:           "ip target type disabled",
+			operandVersion: version.MustParseSemantic("v2.8.2"),
+			controller: &albo.AWSLoadBalancerController{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Spec:       albo.AWSLoadBalancerControllerSpec{TargetType: albo.InstanceTargetType},
+			},
+			existingObjects: []client.Object{
+				&arv1.ValidatingWebhookConfiguration{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "aws-load-balancer-controller-cluster",
+						Annotations: map[string]string{injectCABundleAnnotationKey: injectCABundleAnnotationValue},
+						OwnerReferences: []metav1.OwnerReference{
+							{Name: "cluster", Kind: "AWSLoadBalancerController"},
+						},
+					},
+					Webhooks: testValidatingWebhooks("test-service", "test-namespace"),
+				},
+				&arv1.MutatingWebhookConfiguration{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "aws-load-balancer-controller-cluster",
+						Annotations: map[string]string{injectCABundleAnnotationKey: injectCABundleAnnotationValue},
+						OwnerReferences: []metav1.OwnerReference{
+							{Name: "cluster", Kind: "AWSLoadBalancerController"},
+						},
+					},
+					Webhooks: append(testMutatingWebhooks("test-service", "test-namespace"), testPodMutatingWebhook("test-service", "test-namespace"), testServiceMutatingWebhook("test-service", "test-namespace")),
+				},
+			},
+			webhookService: &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "test-service", Namespace: "test-namespace"}},
+			expectedVWC: &arv1.ValidatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "aws-load-balancer-controller-cluster",
+					Annotations: map[string]string{injectCABundleAnnotationKey: injectCABundleAnnotationValue},
+				},
+				Webhooks: testValidatingWebhooks("test-service", "test-namespace"),
+			},
+			expectedMWC: &arv1.MutatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "aws-load-balancer-controller-cluster",
+					Annotations: map[string]string{injectCABundleAnnotationKey: injectCABundleAnnotationValue},
+				},
+				Webhooks: testMutatingWebhooks("test-service", "test-namespace"),
+			},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			ctx := context.Background()
 			testClient := fake.NewClientBuilder().WithObjects(tc.existingObjects...).WithScheme(test.Scheme).Build()
 			r := &AWSLoadBalancerControllerReconciler{
-				Client: testClient,
-				Scheme: test.Scheme,
+				Client:         testClient,
+				Scheme:         test.Scheme,
+				OperandVersion: tc.operandVersion,
 			}
 			err := r.ensureWebhooks(ctx, tc.controller, tc.webhookService)
 			if err != nil {
@@ -0,0 +1,157 @@
+package operator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"sync/atomic"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/openshift/aws-load-balancer-operator/pkg/aws"
+)
+
+// awsClients holds the AWS clients built from the same credentials.
+type awsClients struct {
+	ec2   aws.EC2Client
+	elbv2 aws.ELBv2Client
+	iam   aws.IAMClient
+	// credentials are the contents of the shared credentials file used to build the clients.
+	credentials []byte
+}
+
+// ClientProvider provides the AWS clients of the operator built from the operator credentials secret.
+// It watches the operator credentials secret: when the credentials are rotated the shared credentials file
+// is rewritten and the clients are swapped atomically. The callers are expected to get the clients
+// from the provider every time they need them instead of keeping them.
+type ClientProvider struct {
+	client          client.Client
+	secretNsName    types.NamespacedName
+	region          string
+	credentialsFile string
+	clients         atomic.Pointer[awsClients]
+
+	newEC2Client   func(ctx context.Context, awsRegion, sharedCredFileName string) (aws.EC2Client, error)
+	newELBv2Client func(ctx context.Context, awsRegion, sharedCredFileName string) (aws.ELBv2Client, error)
+	newIAMClient   func(ctx context.Context, awsRegion, sharedCredFileName string) (aws.IAMClient, error)
+}
+
+// NewClientProvider returns a client provider with the clients built from the given shared credentials file
+// which was provisioned from the operator credentials secret of the given namespace.
+func NewClientProvider(ctx context.Context, client client.Client, secretNamespace, awsRegion, sharedCredFileName string) (*ClientProvider, error) {
+	p := &ClientProvider{
+		client:          client,
+		secretNsName:    types.NamespacedName{Namespace: secretNamespace, Name: operatorCredentialsSecretName},
+		region:          awsRegion,
+		credentialsFile: sharedCredFileName,
+		newEC2Client:    aws.NewClient,
+		newELBv2Client:  aws.NewELBv2Client,
+		newIAMClient:    aws.NewIAMClient,
+	}
+	credentials, err := os.ReadFile(sharedCredFileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials file %q: %w", sharedCredFileName, err)
+	}
+	if err := p.loadClients(ctx, credentials); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// EC2Client returns the current EC2 client.
+func (p *ClientProvider) EC2Client() aws.EC2Client {
+	return p.clients.Load().ec2
+}
+
+// ELBv2Client returns the current ELBv2 client.
+func (p *ClientProvider) ELBv2Client() aws.ELBv2Client {
+	return p.clients.Load().elbv2
+}
+
+// IAMClient returns the current IAM client.
+func (p *ClientProvider) IAMClient() aws.IAMClient {
+	return p.clients.Load().iam
+}
+
+// Reconcile reloads the AWS clients if the credentials from the operator credentials secret
+// differ from the ones the current clients were built with.
+func (p *ClientProvider) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	secret := &corev1.Secret{}
+	if err := p.client.Get(ctx, req.NamespacedName, secret); err != nil {
+		if errors.IsNotFound(err) {
+			// keep the current clients until the secret is provisioned again
+			logger.Info("operator credentials secret not found, keeping the current AWS clients", "secret", req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get operator credentials secret %q: %w", req.NamespacedName, err)
+	}
+
+	credentials := secret.Data[credentialsKey]
+	if current := p.clients.Load(); current != nil && bytes.Equal(current.credentials, credentials) {
+		return ctrl.Result{}, nil
+	}
+
+	logger.Info("operator credentials changed, reloading the AWS clients", "secret", req.NamespacedName)
+	if err := p.replaceCredentialsFile(secret); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to replace credentials file from secret %q: %w", req.NamespacedName, err)
+	}
+	if err := p.loadClients(ctx, credentials); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// loadClients builds the AWS clients from the shared credentials file and swaps the current ones.
+func (p *ClientProvider) loadClients(ctx context.Context, credentials []byte) error {
+	ec2Client, err := p.newEC2Client(ctx, p.region, p.credentialsFile)
+	if err != nil {
+		return fmt.Errorf("failed to make aws client: %w", err)
+	}
+	elbv2Client, err := p.newELBv2Client(ctx, p.region, p.credentialsFile)
+	if err != nil {
+		return fmt.Errorf("failed to make aws elbv2 client: %w", err)
+	}
+	iamClient, err := p.newIAMClient(ctx, p.region, p.credentialsFile)
+	if err != nil {
+		return fmt.Errorf("failed to make aws iam client: %w", err)
+	}
+	p.clients.Store(&awsClients{ec2: ec2Client, elbv2: elbv2Client, iam: iamClient, credentials: credentials})
+	return nil
+}
+
+// SetupWithManager sets up the client provider with the Manager to watch the operator credentials secret.
+func (p *ClientProvider) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("operator-credentials").
+		For(&corev1.Secret{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(o client.Object) bool {
+			return o.GetNamespace() == p.secretNsName.Namespace && o.GetName() == p.secretNsName.Name
+		}))).
+		Complete(p)
+}
+
+// replaceCredentialsFile replaces the contents of the shared credentials file with the credentials from the given secret.
+// The credentials are written to a new file which is renamed so that the readers never see a partial file.
+// The new file is created next to the shared credentials file provisioned by ProvisionCredentials.
+func (p *ClientProvider) replaceCredentialsFile(secret *corev1.Secret) error {
+	fileName, err := credentialsFileFromSecret(secret, credentialsFilePattern)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(fileName)
+
+	if err := os.Rename(fileName, p.credentialsFile); err != nil {
+		return fmt.Errorf("failed to rename %q to %q: %w", fileName, p.credentialsFile, err)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package operator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/openshift/aws-load-balancer-operator/pkg/aws"
+	"github.com/openshift/aws-load-balancer-operator/pkg/utils/test"
+)
+
+// fakeEC2Client is an EC2 client which remembers the credentials it was built with.
+type fakeEC2Client struct {
+	aws.EC2Client
+	credentials string
+}
+
+// fakeIAMClient is an IAM client which remembers the credentials it was built with.
+type fakeIAMClient struct {
+	aws.IAMClient
+	credentials string
+}
+
+// fakeELBv2Client is an ELBv2 client which remembers the credentials it was built with.
+type fakeELBv2Client struct {
+	aws.ELBv2Client
+	credentials string
+}
+
+func Test_ClientProvider(t *testing.T) {
+	tests := []struct {
+		name                string
+		secret              *corev1.Secret
+		expectedCredentials string
+		errExpected         bool
+	}{
+		{
+			name:                "unchanged credentials",
+			secret:              testOperatorCredentialsSecret("initial"),
+			expectedCredentials: "initial",
+		},
+		{
+			name:                "rotated credentials",
+			secret:              testOperatorCredentialsSecret("rotated"),
+			expectedCredentials: "rotated",
+		},
+		{
+			name:                "missing secret",
+			expectedCredentials: "initial",
+		},
+		{
+			name: "secret without credentials",
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "aws-load-balancer-operator", Namespace: "aws-load-balancer-operator"},
+			},
+			expectedCredentials: "initial",
+			errExpected:         true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			credentialsFile := filepath.Join(t.TempDir(), "credentials")
+			if err := os.WriteFile(credentialsFile, []byte("initial"), 0600); err != nil {
+				t.Fatalf("failed to write credentials file: %v", err)
+			}
+
+			var objs []client.Object
+			if tc.secret != nil {
+				objs = append(objs, tc.secret)
+			}
+			cl := fake.NewClientBuilder().WithScheme(test.Scheme).WithObjects(objs...).Build()
+
+			var ec2Loads int
+			p := &ClientProvider{
+				client:          cl,
+				secretNsName:    types.NamespacedName{Namespace: "aws-load-balancer-operator", Name: "aws-load-balancer-operator"},
+				region:          "us-east-1",
+				credentialsFile: credentialsFile,
+				newEC2Client: func(_ context.Context, _, sharedCredFileName string) (aws.EC2Client, error) {
+					ec2Loads++
+					credentials, err := os.ReadFile(sharedCredFileName)
+					return &fakeEC2Client{credentials: string(credentials)}, err
+				},
+				newELBv2Client: func(_ context.Context, _, sharedCredFileName string) (aws.ELBv2Client, error) {
+					credentials, err := os.ReadFile(sharedCredFileName)
+					return &fakeELBv2Client{credentials: string(credentials)}, err
+				},
+				newIAMClient: func(_ context.Context, _, sharedCredFileName string) (aws.IAMClient, error) {
+					credentials, err := os.ReadFile(sharedCredFileName)
+					return &fakeIAMClient{credentials: string(credentials)}, err
+				},
+			}
+			if err := p.loadClients(context.Background(), []byte("initial")); err != nil {
+				t.Fatalf("failed to load initial clients: %v", err)
+			}
+
+			_, err := p.Reconcile(context.Background(), ctrl.Request{NamespacedName: p.secretNsName})
+			if err != nil && !tc.errExpected {
+				t.Fatalf("got unexpected error: %v", err)
+			}
+			if err == nil && tc.errExpected {
+				t.Fatalf("error expected but not received")
+			}
+
+			if got := p.EC2Client().(*fakeEC2Client).credentials; got != tc.expectedCredentials {
+				t.Errorf("unexpected credentials of ec2 client, expected %q, got %q", tc.expectedCredentials, got)
+			}
+			if got := p.ELBv2Client().(*fakeELBv2Client).credentials; got != tc.expectedCredentials {
+				t.Errorf("unexpected credentials of elbv2 client, expected %q, got %q", tc.expectedCredentials, got)
+			}
+			if got := p.IAMClient().(*fakeIAMClient).credentials; got != tc.expectedCredentials {
+				t.Errorf("unexpected credentials of iam client, expected %q, got %q", tc.expectedCredentials, got)
+			}
+			contents, err := os.ReadFile(credentialsFile)
+			if err != nil {
+				t.Fatalf("failed to read credentials file: %v", err)
+			}
+			if string(contents) != tc.expectedCredentials {
+				t.Errorf("unexpected contents of credentials file, expected %q, got %q", tc.expectedCredentials, string(contents))
+			}
+			if tc.expectedCredentials == "initial" && ec2Loads != 1 {
+				t.Errorf("expected clients not to be reloaded, got %d loads", ec2Loads)
+			}
+		})
+	}
+}
+
+func testOperatorCredentialsSecret(credentials string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "aws-load-balancer-operator",
+			Namespace: "aws-load-balancer-operator",
+		},
+		Data: map[string][]byte{
+			"credentials": []byte(credentials),
+		},
+	}
+}
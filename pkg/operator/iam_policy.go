@@ -35,6 +35,50 @@ func GetIAMPolicy() IAMPolicy {
 					"ec2:DescribeVpcs",
 				},
 			},
+			{
+				Effect:          "Allow",
+				Resource:        "*",
+				PolicyCondition: cco.IAMPolicyCondition{},
+				Action: []string{
+					"ec2:DescribeRouteTables",
+					"ec2:DescribeInternetGateways",
+					"ec2:DescribeAvailabilityZones",
+				},
+			},
+			{
+				Effect:          "Allow",
+				Resource:        "*",
+				PolicyCondition: cco.IAMPolicyCondition{},
+				Action: []string{
+					"ec2:DescribeSecurityGroups",
+					"elasticloadbalancing:DescribeLoadBalancers",
+					"elasticloadbalancing:DescribeTargetGroups",
+					"elasticloadbalancing:DescribeTags",
+				},
+			},
+			{
+				Effect:   "Allow",
+				Resource: "*",
+				PolicyCondition: cco.IAMPolicyCondition{
+					"Null": cco.IAMPolicyConditionKeyValue{
+						"aws:ResourceTag/elbv2.k8s.aws/cluster": "false",
+					},
+				},
+				Action: []string{
+					"ec2:DeleteSecurityGroup",
+					"elasticloadbalancing:DeleteLoadBalancer",
+					"elasticloadbalancing:DeleteTargetGroup",
+				},
+			},
+			{
+				Effect:          "Allow",
+				Resource:        "*",
+				PolicyCondition: cco.IAMPolicyCondition{},
+				Action: []string{
+					"iam:SimulatePrincipalPolicy",
+					"iam:GetRole",
+				},
+			},
 		},
 	}
 }
@@ -24,6 +24,7 @@ import (
 	configv1 "github.com/openshift/api/config/v1"
 	cco "github.com/openshift/cloud-credential-operator/pkg/apis/cloudcredential/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	elbv2v1beta1 "sigs.k8s.io/aws-load-balancer-controller/apis/elbv2/v1beta1"
 
 	albo "github.com/openshift/aws-load-balancer-operator/api/v1"
 )
@@ -49,4 +50,5 @@ func init() {
 	utilruntime.Must(configv1.Install(Scheme))
 	utilruntime.Must(cco.Install(Scheme))
 	utilruntime.Must(rbacv1.AddToScheme(Scheme))
+	utilruntime.Must(elbv2v1beta1.AddToScheme(Scheme))
 }